@@ -5,51 +5,107 @@ import (
 	"log/slog"
 
 	"github.com/a-skua/etk/craft"
-	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/event"
 	"github.com/a-skua/etk/craft/types"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 type ActionHandler[T craft.Craft] func(T) error
 
+// wrapper is the transparent part of the actions.
+// Image, Size, Const and Update are the wrapped craft's,
+// and events hit-test the wrapped craft as the only child.
+// AddText, SetText and ClearText are left to each action, so that they return the action itself.
+type wrapper[T craft.Craft] struct {
+	craft T
+}
+
+func (w wrapper[T]) Image() *ebiten.Image {
+	return w.craft.Image()
+}
+
+func (w wrapper[T]) Size() types.Size {
+	return w.craft.Size()
+}
+
+func (w wrapper[T]) SetDirection(d types.Direction) {
+	craft.SetDirection(w.craft, d)
+}
+
+func (w wrapper[T]) Const() *craft.Image {
+	return w.craft.Const()
+}
+
+func (w wrapper[T]) Update(p types.Position) error {
+	return w.craft.Update(p)
+}
+
+func (w wrapper[T]) Children() []event.Child {
+	return []event.Child{{Target: w.craft}}
+}
+
+// call calls handler if it is set.
+func call[T craft.Craft](handler ActionHandler[T], c T) error {
+	if handler == nil {
+		return nil
+	}
+	return handler(c)
+}
+
+// MousePressed
+//
+// MousePressed calls the handler when the button is pressed on the wrapped craft.
+// It needs the events dispatched by etk.Game.
+// Use Click to also require the button to be released on the craft.
+//
+// MousePressed is transparent: text is delegated to the wrapped craft,
+// but AddText, SetText and ClearText return the wrapper itself.
 type MousePressed[T craft.Craft] struct {
-	craft   T
+	wrapper[T]
 	button  ebiten.MouseButton
 	handler ActionHandler[T]
 }
 
 func NewMousePressed[T craft.Craft](craft T, button ebiten.MouseButton, handler ActionHandler[T]) *MousePressed[T] {
-	return &MousePressed[T]{craft, button, handler}
+	return &MousePressed[T]{wrapper[T]{craft}, button, handler}
 }
 
-func (m *MousePressed[T]) Image() *ebiten.Image {
-	return m.craft.Image()
+func (m *MousePressed[T]) AddText(str string, color color.Color) craft.Self {
+	m.craft.AddText(str, color)
+	return m
 }
 
-func (m *MousePressed[T]) Size() types.Size {
-	return m.craft.Size()
+func (m *MousePressed[T]) SetText(str string, color color.Color) craft.Self {
+	m.craft.SetText(str, color)
+	return m
 }
 
-func (m *MousePressed[T]) AddText(str string, color color.Color) craft.Self {
-	return m.craft.AddText(str, color)
+func (m *MousePressed[T]) ClearText() craft.Self {
+	m.craft.ClearText()
+	return m
 }
 
-func (m *MousePressed[T]) Const() *craft.Image {
-	return m.craft.Const()
+// CursorShape is a pointer, since the wrapped craft can be pressed.
+func (m *MousePressed[T]) CursorShape() ebiten.CursorShapeType {
+	return ebiten.CursorShapePointer
 }
 
-func (m *MousePressed[T]) Update(p types.Position) error {
-	if !inpututil.IsMouseButtonJustPressed(m.button) {
+func (m *MousePressed[T]) Focusable() bool {
+	return true
+}
+
+// HandleEvent calls the handler when the button is pressed on the wrapped craft,
+// or when it is focused and activated with Enter or Space.
+// The event stops there, so crafts under or around it are not pressed as well.
+func (m *MousePressed[T]) HandleEvent(e *event.Event) error {
+	if e.Phase == event.Capture {
 		return nil
 	}
-
-	x, y := ebiten.CursorPosition()
-	p = types.Position{X: x, Y: y}.Sub(p)
-	if !util.Sizein(m.craft.Size(), p) {
+	if e.Type != event.Activate && (e.Type != event.PointerDown || e.Button != m.button) {
 		return nil
 	}
+	e.StopPropagation()
 
-	slog.Debug("MousePressed.Update")
+	slog.Debug("MousePressed.HandleEvent")
 	return m.handler(m.craft)
 }
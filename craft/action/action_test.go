@@ -1,10 +1,14 @@
 package action
 
 import (
+	"fmt"
 	"image/color"
+	"testing"
 
 	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
 	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -15,3 +19,78 @@ var _ craft.Craft = NewMousePressed(
 		return nil
 	},
 )
+
+func TestMousePressedText(t *testing.T) {
+	fill := craft.NewFill(types.Size{X: 10, Y: 10}, color.White)
+	m := NewMousePressed(fill, ebiten.MouseButtonLeft, func(f *craft.Fill) error { return nil })
+
+	if c := m.AddText("a", color.White); c != m {
+		t.Errorf("AddText should return itself, but got %v", c)
+	}
+	if c := m.SetText("b", color.White); c != m {
+		t.Errorf("SetText should return itself, but got %v", c)
+	}
+	if c := m.ClearText(); c != m {
+		t.Errorf("ClearText should return itself, but got %v", c)
+	}
+}
+
+func TestMousePressedHandleEvent(t *testing.T) {
+	tests := []struct {
+		button ebiten.MouseButton
+		want   int
+	}{
+		{ebiten.MouseButtonLeft, 1},
+		{ebiten.MouseButtonRight, 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			count := 0
+			m := NewMousePressed(
+				craft.NewFill(types.Size{X: 10, Y: 10}, color.White),
+				ebiten.MouseButtonLeft,
+				func(f *craft.Fill) error { count++; return nil },
+			)
+			// m is not pressed when the button is outside of it.
+			root := craft.NewHorizontalStack(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), m)
+
+			in := input.New()
+			d := event.NewDispatcher()
+			for _, x := range []int{5, 15} {
+				in.Update(input.State{Cursor: types.Position{X: x, Y: 5}, Buttons: []ebiten.MouseButton{tt.button}})
+				d.Dispatch(root, in)
+				in.Update(input.State{Cursor: types.Position{X: x, Y: 5}})
+				d.Dispatch(root, in)
+			}
+
+			if count != tt.want {
+				t.Errorf("handler should be called %d times, but got %d", tt.want, count)
+			}
+		})
+	}
+}
+
+func TestMousePressedActivate(t *testing.T) {
+	count := 0
+	m := NewMousePressed(
+		craft.NewFill(types.Size{X: 10, Y: 10}, color.White),
+		ebiten.MouseButtonLeft,
+		func(f *craft.Fill) error { count++; return nil },
+	)
+	root := craft.NewHorizontalStack(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), m)
+
+	in := input.New()
+	d := event.NewDispatcher()
+	for _, keys := range [][]ebiten.Key{{ebiten.KeyTab}, {}, {ebiten.KeyEnter}, {}} {
+		in.Update(input.State{Keys: keys})
+		d.Dispatch(root, in)
+	}
+
+	if d.Focused() != m {
+		t.Errorf("Focused should return %v, but got %v", m, d.Focused())
+	}
+	if count != 1 {
+		t.Errorf("handler should be called 1 time, but got %d", count)
+	}
+}
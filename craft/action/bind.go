@@ -0,0 +1,62 @@
+package action
+
+import (
+	"image/color"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/input"
+)
+
+// Action
+//
+// Action calls the handler when an input bound to the named action reaches the wrapped craft:
+// a key or a gamepad button while it is focused, or a mouse button pressed on it.
+// The bindings are looked up when the event arrives, so rebinding takes effect at once.
+type Action[T craft.Craft] struct {
+	wrapper[T]
+	actions *input.Map
+	name    string
+	handler ActionHandler[T]
+}
+
+func NewAction[T craft.Craft](craft T, actions *input.Map, name string, handler ActionHandler[T]) *Action[T] {
+	return &Action[T]{wrapper[T]{craft}, actions, name, handler}
+}
+
+func (a *Action[T]) AddText(str string, color color.Color) craft.Self {
+	a.craft.AddText(str, color)
+	return a
+}
+
+func (a *Action[T]) SetText(str string, color color.Color) craft.Self {
+	a.craft.SetText(str, color)
+	return a
+}
+
+func (a *Action[T]) ClearText() craft.Self {
+	a.craft.ClearText()
+	return a
+}
+
+func (a *Action[T]) Focusable() bool {
+	return true
+}
+
+// HandleEvent calls the handler on a KeyDown, PointerDown or GamepadDown bound to the action.
+// Repeats are ignored, and the event stops there.
+func (a *Action[T]) HandleEvent(e *event.Event) error {
+	if e.Phase == event.Capture || e.Repeat {
+		return nil
+	}
+	switch e.Type {
+	case event.KeyDown, event.PointerDown, event.GamepadDown:
+	default:
+		return nil
+	}
+	if !e.Is(a.actions, a.name) {
+		return nil
+	}
+	e.StopPropagation()
+	return call(a.handler, a.craft)
+}
@@ -0,0 +1,70 @@
+package action
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ craft.Craft = NewAction(
+	craft.NewFill(types.Size{X: 10, Y: 10}, color.White),
+	input.DefaultMap(),
+	input.ActionConfirm,
+	func(f *craft.Fill) error { return nil },
+)
+
+func TestActionHandleEvent(t *testing.T) {
+	actions := input.NewMap().Bind("jump", input.KeyBinding(ebiten.KeyJ), input.MouseBinding(ebiten.MouseButtonRight))
+	count := 0
+	a := NewAction(
+		craft.NewFill(types.Size{X: 10, Y: 10}, color.White),
+		actions,
+		"jump",
+		func(f *craft.Fill) error { count++; return nil },
+	)
+	root := craft.NewHorizontalStack(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), a)
+
+	in := input.New()
+	d := event.NewDispatcher()
+	tick := func(s input.State) {
+		in.Update(s)
+		d.Dispatch(root, in)
+	}
+
+	tests := []struct {
+		states []input.State
+		want   int
+	}{
+		// not focused yet
+		{[]input.State{{Keys: []ebiten.Key{ebiten.KeyJ}}, {}}, 0},
+		{[]input.State{{Cursor: types.Position{X: 15, Y: 5}, Buttons: []ebiten.MouseButton{ebiten.MouseButtonRight}}, {}}, 1},
+		// focused by the press, held keys do not repeat it
+		{[]input.State{{Keys: []ebiten.Key{ebiten.KeyJ}}, {Keys: []ebiten.Key{ebiten.KeyJ}}, {}}, 2},
+		{[]input.State{{Keys: []ebiten.Key{ebiten.KeyK}}, {}}, 2},
+		{[]input.State{{Cursor: types.Position{X: 15, Y: 5}, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}}, {}}, 2},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			for _, s := range tt.states {
+				tick(s)
+			}
+			if count != tt.want {
+				t.Errorf("handler should be called %d times, but got %d", tt.want, count)
+			}
+		})
+	}
+
+	// Rebinding takes effect at once.
+	actions.Rebind("jump", input.KeyBinding(ebiten.KeyK))
+	tick(input.State{Keys: []ebiten.Key{ebiten.KeyK}})
+	if count != 3 {
+		t.Errorf("handler should be called 3 times, but got %d", count)
+	}
+}
@@ -0,0 +1,250 @@
+package action
+
+import (
+	"image/color"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// DefaultDragThreshold is the distance in pixels the pointer must move
+// with the button held before a press becomes a drag.
+// Shorter moves are still clicks.
+const DefaultDragThreshold = 4
+
+var defaultDropHighlight = color.RGBA{0x40, 0x40, 0x40, 0x40}
+
+type DragEndHandler[T craft.Craft] func(c T, accepted bool) error
+
+type DropHandler[T craft.Craft, P any] func(c T, payload P) error
+
+// Draggable
+//
+// Draggable picks up the wrapped craft when it is pressed with the left button
+// and moved past the threshold.
+// While dragged, a ghost of the craft follows the cursor and the payload can be
+// dropped on a DropTarget.
+// Draggable is transparent like MousePressed.
+type Draggable[T craft.Craft, P any] struct {
+	wrapper[T]
+	payload   P
+	threshold int
+	pressed   bool
+	origin    types.Position
+	grab      types.Position
+	drag      *event.Drag
+	onStart   ActionHandler[T]
+	onEnd     DragEndHandler[T]
+}
+
+func NewDraggable[T craft.Craft, P any](craft T, payload P) *Draggable[T, P] {
+	return &Draggable[T, P]{wrapper[T]{craft}, payload, DefaultDragThreshold, false, types.Position{}, types.Position{}, nil, nil, nil}
+}
+
+// SetThreshold sets the distance in pixels before a press becomes a drag.
+func (d *Draggable[T, P]) SetThreshold(threshold int) *Draggable[T, P] {
+	d.threshold = threshold
+	return d
+}
+
+func (d *Draggable[T, P]) OnDragStart(handler ActionHandler[T]) *Draggable[T, P] {
+	d.onStart = handler
+	return d
+}
+
+// OnDragEnd sets the handler called when the drag ends,
+// with whether a DropTarget accepted the payload.
+func (d *Draggable[T, P]) OnDragEnd(handler DragEndHandler[T]) *Draggable[T, P] {
+	d.onEnd = handler
+	return d
+}
+
+func (d *Draggable[T, P]) Payload() P {
+	return d.payload
+}
+
+func (d *Draggable[T, P]) SetPayload(payload P) *Draggable[T, P] {
+	d.payload = payload
+	return d
+}
+
+// Dragging reports whether the wrapped craft is being dragged.
+func (d *Draggable[T, P]) Dragging() bool {
+	return d.drag != nil
+}
+
+func (d *Draggable[T, P]) AddText(str string, color color.Color) craft.Self {
+	d.craft.AddText(str, color)
+	return d
+}
+
+func (d *Draggable[T, P]) SetText(str string, color color.Color) craft.Self {
+	d.craft.SetText(str, color)
+	return d
+}
+
+func (d *Draggable[T, P]) ClearText() craft.Self {
+	d.craft.ClearText()
+	return d
+}
+
+func (d *Draggable[T, P]) CursorShape() ebiten.CursorShapeType {
+	if d.drag != nil {
+		return ebiten.CursorShapeMove
+	}
+	return ebiten.CursorShapeDefault
+}
+
+// HandleEvent handles the events before the wrapped craft does, without stopping them,
+// so that the wrapped craft can still be clicked.
+func (d *Draggable[T, P]) HandleEvent(e *event.Event) error {
+	if e.Phase == event.Bubble {
+		return nil
+	}
+
+	switch e.Type {
+	case event.PointerDown:
+		if e.Button != ebiten.MouseButtonLeft {
+			return nil
+		}
+		d.pressed = true
+		d.origin = e.Position
+		d.grab = e.Local()
+	case event.PointerMove:
+		if !d.pressed || d.drag != nil {
+			return nil
+		}
+		delta := e.Position.Sub(d.origin)
+		if delta.X*delta.X+delta.Y*delta.Y <= d.threshold*d.threshold {
+			return nil
+		}
+		d.drag = &event.Drag{
+			Payload: d.payload,
+			Ghost:   d.craft.Image(),
+			Offset:  types.Position{X: -d.grab.X, Y: -d.grab.Y},
+		}
+		e.StartDrag(d.drag)
+		return call(d.onStart, d.craft)
+	case event.PointerUp:
+		if e.Button != ebiten.MouseButtonLeft {
+			return nil
+		}
+		d.pressed = false
+		if d.drag == nil {
+			return nil
+		}
+		accepted := d.drag.Accepted()
+		d.drag = nil
+		if d.onEnd != nil {
+			return d.onEnd(d.craft, accepted)
+		}
+	}
+	return nil
+}
+
+// DropTarget
+//
+// DropTarget receives the payloads of type P dropped on the wrapped craft.
+// Payloads of other types, or rejected by the accept function, are ignored.
+// While an acceptable payload is dragged over it, the craft is highlighted.
+type DropTarget[T craft.Craft, P any] struct {
+	wrapper[T]
+	handler   DropHandler[T, P]
+	accept    func(P) bool
+	highlight color.Color
+	over      bool
+}
+
+func NewDropTarget[T craft.Craft, P any](craft T, handler DropHandler[T, P]) *DropTarget[T, P] {
+	return &DropTarget[T, P]{wrapper[T]{craft}, handler, nil, defaultDropHighlight, false}
+}
+
+// Accept sets the function deciding whether a payload can be dropped.
+func (d *DropTarget[T, P]) Accept(accept func(P) bool) *DropTarget[T, P] {
+	d.accept = accept
+	return d
+}
+
+func (d *DropTarget[T, P]) SetHighlight(color color.Color) *DropTarget[T, P] {
+	d.highlight = color
+	return d
+}
+
+// Over reports whether an acceptable payload is dragged over the wrapped craft.
+func (d *DropTarget[T, P]) Over() bool {
+	return d.over
+}
+
+func (d *DropTarget[T, P]) Image() *ebiten.Image {
+	if !d.over {
+		return d.craft.Image()
+	}
+
+	size := d.Size()
+	image := ebiten.NewImage(size.X, size.Y)
+	image.DrawImage(d.craft.Image(), nil)
+	vector.DrawFilledRect(image, 0, 0, float32(size.X), float32(size.Y), d.highlight, false)
+	return image
+}
+
+func (d *DropTarget[T, P]) Const() *craft.Image {
+	return d.craft.Const()
+}
+
+func (d *DropTarget[T, P]) AddText(str string, color color.Color) craft.Self {
+	d.craft.AddText(str, color)
+	return d
+}
+
+func (d *DropTarget[T, P]) SetText(str string, color color.Color) craft.Self {
+	d.craft.SetText(str, color)
+	return d
+}
+
+func (d *DropTarget[T, P]) ClearText() craft.Self {
+	d.craft.ClearText()
+	return d
+}
+
+// payload returns the payload of the current drag if it can be dropped.
+func (d *DropTarget[T, P]) payload(e *event.Event) (P, bool) {
+	var zero P
+	drag := e.Drag()
+	if drag == nil {
+		return zero, false
+	}
+	p, ok := drag.Payload.(P)
+	if !ok || (d.accept != nil && !d.accept(p)) {
+		return zero, false
+	}
+	return p, true
+}
+
+func (d *DropTarget[T, P]) HandleEvent(e *event.Event) error {
+	switch e.Type {
+	case event.PointerEnter:
+		_, d.over = d.payload(e)
+	case event.PointerMove:
+		if e.Phase != event.Bubble {
+			_, d.over = d.payload(e)
+		}
+	case event.PointerLeave:
+		d.over = false
+	case event.Drop:
+		if e.Phase == event.Capture {
+			return nil
+		}
+		d.over = false
+		p, ok := d.payload(e)
+		if !ok {
+			return nil
+		}
+		e.Drag().Accept()
+		e.StopPropagation()
+		return d.handler(d.craft, p)
+	}
+	return nil
+}
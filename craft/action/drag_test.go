@@ -0,0 +1,116 @@
+package action
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ craft.Craft = NewDraggable(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), 1)
+var _ craft.Craft = NewDropTarget(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), func(*craft.Fill, int) error { return nil })
+
+func TestDragAndDrop(t *testing.T) {
+	type result struct {
+		dropped  []int
+		accepted []bool
+		clicks   int
+	}
+
+	tests := []struct {
+		ticks []tick
+		want  result
+	}{
+		// dropped on the target
+		{[]tick{{5, true}, {10, true}, {25, true}, {25, false}}, result{[]int{7}, []bool{true}, 0}},
+		// dropped outside of the target
+		{[]tick{{5, true}, {15, true}, {15, false}}, result{[]int{}, []bool{false}, 0}},
+		// rejected by the target
+		{[]tick{{5, true}, {10, true}, {35, true}, {35, false}}, result{[]int{}, []bool{false}, 0}},
+		// under the threshold, so a click
+		{[]tick{{5, true}, {7, true}, {7, false}}, result{[]int{}, []bool{}, 1}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			got := result{[]int{}, []bool{}, 0}
+			source := NewDraggable(
+				NewClick(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), ebiten.MouseButtonLeft,
+					func(*craft.Fill) error { got.clicks++; return nil }),
+				7,
+			).OnDragEnd(func(_ *Click[*craft.Fill], accepted bool) error {
+				got.accepted = append(got.accepted, accepted)
+				return nil
+			})
+			drop := func(_ *craft.Fill, p int) error {
+				got.dropped = append(got.dropped, p)
+				return nil
+			}
+			root := craft.NewHorizontalStack(
+				source,
+				craft.NewFill(types.Size{X: 10, Y: 10}, color.White),
+				NewDropTarget(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), drop),
+				NewDropTarget(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), drop).
+					Accept(func(p int) bool { return p > 10 }),
+			)
+
+			in := input.New()
+			d := event.NewDispatcher()
+			for _, tk := range tt.ticks {
+				s := input.State{Cursor: types.Position{X: tk.x, Y: 5}}
+				if tk.pressed {
+					s.Buttons = []ebiten.MouseButton{ebiten.MouseButtonLeft}
+				}
+				in.Update(s)
+				if err := d.Dispatch(root, in); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("drag should result in %v, but got %v", tt.want, got)
+			}
+			if d.Dragging() != nil {
+				t.Errorf("Dragging should return nil after the release, but got %v", d.Dragging())
+			}
+		})
+	}
+}
+
+func TestDropTargetOver(t *testing.T) {
+	target := NewDropTarget(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), func(*craft.Fill, string) error { return nil })
+	root := craft.NewHorizontalStack(NewDraggable(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), "a"), target)
+
+	tests := []struct {
+		tick tick
+		want bool
+	}{
+		{tick{5, true}, false},
+		{tick{15, true}, true},
+		{tick{5, true}, false},
+		{tick{15, true}, true},
+		{tick{15, false}, false},
+	}
+
+	in := input.New()
+	d := event.NewDispatcher()
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			s := input.State{Cursor: types.Position{X: tt.tick.x, Y: 5}}
+			if tt.tick.pressed {
+				s.Buttons = []ebiten.MouseButton{ebiten.MouseButtonLeft}
+			}
+			in.Update(s)
+			d.Dispatch(root, in)
+
+			if target.Over() != tt.want {
+				t.Errorf("Over should return %v, but got %v", tt.want, target.Over())
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package action
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Gestures are measured in ticks counted by Update, not in wall-clock time,
+// so the same input always produces the same gestures.
+const (
+	DefaultDoubleClickInterval = 30
+	DefaultLongPressDuration   = 45
+)
+
+// press tracks a button pressed on a craft until it is released.
+type press struct {
+	button  ebiten.MouseButton
+	pressed bool
+}
+
+// down reports whether e presses the button, and starts tracking it.
+func (p *press) down(e *event.Event) bool {
+	if e.Type != event.PointerDown || e.Phase == event.Capture || e.Button != p.button {
+		return false
+	}
+	p.pressed = true
+	e.StopPropagation()
+	return true
+}
+
+// up reports whether e releases the tracked button, and stops tracking it.
+func (p *press) up(e *event.Event) bool {
+	if e.Type != event.PointerUp || e.Phase == event.Capture || e.Button != p.button || !p.pressed {
+		return false
+	}
+	p.pressed = false
+	return true
+}
+
+// Click
+//
+// Click calls the handler when the button is pressed and then released on the wrapped craft.
+// A press dragged off the craft before it is released, or dragged by a Draggable, is not a click.
+type Click[T craft.Craft] struct {
+	wrapper[T]
+	press
+	handler ActionHandler[T]
+}
+
+func NewClick[T craft.Craft](craft T, button ebiten.MouseButton, handler ActionHandler[T]) *Click[T] {
+	return &Click[T]{wrapper[T]{craft}, press{button: button}, handler}
+}
+
+func (c *Click[T]) AddText(str string, color color.Color) craft.Self {
+	c.craft.AddText(str, color)
+	return c
+}
+
+func (c *Click[T]) SetText(str string, color color.Color) craft.Self {
+	c.craft.SetText(str, color)
+	return c
+}
+
+func (c *Click[T]) ClearText() craft.Self {
+	c.craft.ClearText()
+	return c
+}
+
+func (c *Click[T]) CursorShape() ebiten.CursorShapeType {
+	return ebiten.CursorShapePointer
+}
+
+func (c *Click[T]) Focusable() bool {
+	return true
+}
+
+// HandleEvent also calls the handler when the wrapped craft is focused and activated.
+func (c *Click[T]) HandleEvent(e *event.Event) error {
+	if e.Type == event.Activate && e.Phase != event.Capture {
+		e.StopPropagation()
+		return call(c.handler, c.craft)
+	}
+	if c.down(e) {
+		return nil
+	}
+	if c.up(e) && e.Inside() && e.Drag() == nil {
+		e.StopPropagation()
+		return call(c.handler, c.craft)
+	}
+	return nil
+}
+
+// DoubleClick
+//
+// DoubleClick calls the handler when the wrapped craft is clicked twice
+// within the interval, counted in ticks from the first click.
+type DoubleClick[T craft.Craft] struct {
+	wrapper[T]
+	press
+	handler  ActionHandler[T]
+	interval int
+	ticks    int
+	clicked  bool
+}
+
+func NewDoubleClick[T craft.Craft](craft T, button ebiten.MouseButton, handler ActionHandler[T]) *DoubleClick[T] {
+	return &DoubleClick[T]{wrapper[T]{craft}, press{button: button}, handler, DefaultDoubleClickInterval, 0, false}
+}
+
+// SetInterval sets the maximum number of ticks between the two clicks.
+func (d *DoubleClick[T]) SetInterval(ticks int) *DoubleClick[T] {
+	d.interval = ticks
+	return d
+}
+
+func (d *DoubleClick[T]) AddText(str string, color color.Color) craft.Self {
+	d.craft.AddText(str, color)
+	return d
+}
+
+func (d *DoubleClick[T]) SetText(str string, color color.Color) craft.Self {
+	d.craft.SetText(str, color)
+	return d
+}
+
+func (d *DoubleClick[T]) ClearText() craft.Self {
+	d.craft.ClearText()
+	return d
+}
+
+func (d *DoubleClick[T]) CursorShape() ebiten.CursorShapeType {
+	return ebiten.CursorShapePointer
+}
+
+func (d *DoubleClick[T]) Update(p types.Position) error {
+	if d.clicked {
+		d.ticks++
+		if d.ticks > d.interval {
+			d.clicked = false
+		}
+	}
+	return d.craft.Update(p)
+}
+
+func (d *DoubleClick[T]) HandleEvent(e *event.Event) error {
+	if d.down(e) {
+		return nil
+	}
+	if !d.up(e) || !e.Inside() || e.Drag() != nil {
+		return nil
+	}
+	e.StopPropagation()
+
+	if !d.clicked {
+		d.clicked = true
+		d.ticks = 0
+		return nil
+	}
+	d.clicked = false
+	return call(d.handler, d.craft)
+}
+
+// LongPress
+//
+// LongPress calls the handler once the button has been held on the wrapped craft
+// for the duration, counted in ticks.
+// Releasing the button or moving the pointer off the craft cancels it.
+type LongPress[T craft.Craft] struct {
+	wrapper[T]
+	press
+	handler  ActionHandler[T]
+	duration int
+	ticks    int
+}
+
+func NewLongPress[T craft.Craft](craft T, button ebiten.MouseButton, handler ActionHandler[T]) *LongPress[T] {
+	return &LongPress[T]{wrapper[T]{craft}, press{button: button}, handler, DefaultLongPressDuration, 0}
+}
+
+// SetDuration sets the number of ticks the button must be held.
+func (l *LongPress[T]) SetDuration(ticks int) *LongPress[T] {
+	l.duration = ticks
+	return l
+}
+
+func (l *LongPress[T]) AddText(str string, color color.Color) craft.Self {
+	l.craft.AddText(str, color)
+	return l
+}
+
+func (l *LongPress[T]) SetText(str string, color color.Color) craft.Self {
+	l.craft.SetText(str, color)
+	return l
+}
+
+func (l *LongPress[T]) ClearText() craft.Self {
+	l.craft.ClearText()
+	return l
+}
+
+func (l *LongPress[T]) Update(p types.Position) (err error) {
+	if l.pressed {
+		l.ticks++
+		if l.ticks == l.duration {
+			l.pressed = false
+			err = call(l.handler, l.craft)
+		}
+	}
+	return errors.Join(err, l.craft.Update(p))
+}
+
+func (l *LongPress[T]) HandleEvent(e *event.Event) error {
+	switch {
+	case l.down(e):
+		l.ticks = 0
+	case l.up(e):
+	case e.Type == event.PointerLeave:
+		l.pressed = false
+	}
+	return nil
+}
+
+// Released
+//
+// Released calls the handler when the button pressed on the wrapped craft is released,
+// wherever the pointer is.
+type Released[T craft.Craft] struct {
+	wrapper[T]
+	press
+	handler ActionHandler[T]
+}
+
+func NewReleased[T craft.Craft](craft T, button ebiten.MouseButton, handler ActionHandler[T]) *Released[T] {
+	return &Released[T]{wrapper[T]{craft}, press{button: button}, handler}
+}
+
+func (r *Released[T]) AddText(str string, color color.Color) craft.Self {
+	r.craft.AddText(str, color)
+	return r
+}
+
+func (r *Released[T]) SetText(str string, color color.Color) craft.Self {
+	r.craft.SetText(str, color)
+	return r
+}
+
+func (r *Released[T]) ClearText() craft.Self {
+	r.craft.ClearText()
+	return r
+}
+
+func (r *Released[T]) HandleEvent(e *event.Event) error {
+	if r.down(e) {
+		return nil
+	}
+	if r.up(e) {
+		e.StopPropagation()
+		return call(r.handler, r.craft)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package action
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ craft.Craft = NewClick(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), ebiten.MouseButtonLeft, nil)
+var _ craft.Craft = NewDoubleClick(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), ebiten.MouseButtonLeft, nil)
+var _ craft.Craft = NewLongPress(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), ebiten.MouseButtonLeft, nil)
+var _ craft.Craft = NewReleased(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), ebiten.MouseButtonLeft, nil)
+
+// tick is the input of a tick: the cursor x and whether the left button is held.
+type tick struct {
+	x       int
+	pressed bool
+}
+
+// play runs the ticks on a stack of a plain fill and c, like Game does.
+func play(t *testing.T, c craft.Craft, ticks []tick) {
+	t.Helper()
+	root := craft.NewHorizontalStack(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), c)
+	in := input.New()
+	d := event.NewDispatcher()
+	for _, tk := range ticks {
+		s := input.State{Cursor: types.Position{X: tk.x, Y: 5}}
+		if tk.pressed {
+			s.Buttons = []ebiten.MouseButton{ebiten.MouseButtonLeft}
+		}
+		in.Update(s)
+		if err := d.Dispatch(root, in); err != nil {
+			t.Fatal(err)
+		}
+		if err := root.Update(types.Position{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// repeat returns n copies of tk.
+func repeat(tk tick, n int) []tick {
+	ticks := make([]tick, n)
+	for i := range ticks {
+		ticks[i] = tk
+	}
+	return ticks
+}
+
+func TestClick(t *testing.T) {
+	tests := []struct {
+		ticks []tick
+		want  int
+	}{
+		{[]tick{{15, true}, {15, false}}, 1},
+		{[]tick{{15, true}, {16, true}, {16, false}}, 1},
+		{[]tick{{15, true}, {5, true}, {5, false}}, 0},
+		{[]tick{{5, true}, {15, true}, {15, false}}, 0},
+		{[]tick{{15, true}, {5, true}, {15, true}, {15, false}}, 1},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			count := 0
+			play(t, NewClick(
+				craft.NewFill(types.Size{X: 10, Y: 10}, color.White),
+				ebiten.MouseButtonLeft,
+				func(*craft.Fill) error { count++; return nil },
+			), tt.ticks)
+
+			if count != tt.want {
+				t.Errorf("handler should be called %d times, but got %d", tt.want, count)
+			}
+		})
+	}
+}
+
+func TestDoubleClick(t *testing.T) {
+	click := []tick{{15, true}, {15, false}}
+
+	tests := []struct {
+		ticks []tick
+		want  int
+	}{
+		{click, 0},
+		{append(append(click, repeat(tick{15, false}, 5)...), click...), 1},
+		{append(append(click, repeat(tick{15, false}, 10)...), click...), 0},
+		{append(append(append(click, click...), click...), click...), 2},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			count := 0
+			play(t, NewDoubleClick(
+				craft.NewFill(types.Size{X: 10, Y: 10}, color.White),
+				ebiten.MouseButtonLeft,
+				func(*craft.Fill) error { count++; return nil },
+			).SetInterval(8), tt.ticks)
+
+			if count != tt.want {
+				t.Errorf("handler should be called %d times, but got %d", tt.want, count)
+			}
+		})
+	}
+}
+
+func TestLongPress(t *testing.T) {
+	tests := []struct {
+		ticks []tick
+		want  int
+	}{
+		{repeat(tick{15, true}, 10), 1},
+		{repeat(tick{15, true}, 20), 1},
+		{repeat(tick{15, true}, 9), 0},
+		{append(repeat(tick{15, true}, 5), repeat(tick{15, false}, 10)...), 0},
+		{append(repeat(tick{15, true}, 5), repeat(tick{5, true}, 10)...), 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			count := 0
+			play(t, NewLongPress(
+				craft.NewFill(types.Size{X: 10, Y: 10}, color.White),
+				ebiten.MouseButtonLeft,
+				func(*craft.Fill) error { count++; return nil },
+			).SetDuration(10), tt.ticks)
+
+			if count != tt.want {
+				t.Errorf("handler should be called %d times, but got %d", tt.want, count)
+			}
+		})
+	}
+}
+
+func TestReleased(t *testing.T) {
+	tests := []struct {
+		ticks []tick
+		want  int
+	}{
+		{[]tick{{15, true}, {15, false}}, 1},
+		{[]tick{{15, true}, {5, true}, {5, false}}, 1},
+		{[]tick{{5, true}, {15, true}, {15, false}}, 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			count := 0
+			play(t, NewReleased(
+				craft.NewFill(types.Size{X: 10, Y: 10}, color.White),
+				ebiten.MouseButtonLeft,
+				func(*craft.Fill) error { count++; return nil },
+			), tt.ticks)
+
+			if count != tt.want {
+				t.Errorf("handler should be called %d times, but got %d", tt.want, count)
+			}
+		})
+	}
+}
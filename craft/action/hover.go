@@ -0,0 +1,89 @@
+package action
+
+import (
+	"image/color"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Hover
+//
+// Hover tracks whether the pointer is over the wrapped craft.
+// OnEnter and OnLeave are called when the pointer enters and leaves it,
+// and OnMove whenever the pointer moves over it.
+// Hover is transparent like MousePressed.
+type Hover[T craft.Craft] struct {
+	wrapper[T]
+	hovered bool
+	cursor  ebiten.CursorShapeType
+	onEnter ActionHandler[T]
+	onLeave ActionHandler[T]
+	onMove  ActionHandler[T]
+}
+
+func NewHover[T craft.Craft](craft T) *Hover[T] {
+	return &Hover[T]{wrapper[T]{craft}, false, ebiten.CursorShapeDefault, nil, nil, nil}
+}
+
+func (h *Hover[T]) OnEnter(handler ActionHandler[T]) *Hover[T] {
+	h.onEnter = handler
+	return h
+}
+
+func (h *Hover[T]) OnLeave(handler ActionHandler[T]) *Hover[T] {
+	h.onLeave = handler
+	return h
+}
+
+func (h *Hover[T]) OnMove(handler ActionHandler[T]) *Hover[T] {
+	h.onMove = handler
+	return h
+}
+
+// SetCursor sets the cursor shape while hovered.
+func (h *Hover[T]) SetCursor(shape ebiten.CursorShapeType) *Hover[T] {
+	h.cursor = shape
+	return h
+}
+
+// Hovered reports whether the pointer is over the wrapped craft.
+func (h *Hover[T]) Hovered() bool {
+	return h.hovered
+}
+
+func (h *Hover[T]) AddText(str string, color color.Color) craft.Self {
+	h.craft.AddText(str, color)
+	return h
+}
+
+func (h *Hover[T]) SetText(str string, color color.Color) craft.Self {
+	h.craft.SetText(str, color)
+	return h
+}
+
+func (h *Hover[T]) ClearText() craft.Self {
+	h.craft.ClearText()
+	return h
+}
+
+func (h *Hover[T]) CursorShape() ebiten.CursorShapeType {
+	return h.cursor
+}
+
+func (h *Hover[T]) HandleEvent(e *event.Event) error {
+	switch e.Type {
+	case event.PointerEnter:
+		h.hovered = true
+		return call(h.onEnter, h.craft)
+	case event.PointerLeave:
+		h.hovered = false
+		return call(h.onLeave, h.craft)
+	case event.PointerMove:
+		if h.hovered && e.Phase != event.Capture {
+			return call(h.onMove, h.craft)
+		}
+	}
+	return nil
+}
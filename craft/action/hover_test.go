@@ -0,0 +1,86 @@
+package action
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ craft.Craft = NewHover(craft.NewFill(types.Size{X: 10, Y: 10}, color.White))
+
+func TestHover(t *testing.T) {
+	log := []string{}
+	h := NewHover(craft.NewFill(types.Size{X: 10, Y: 10}, color.White)).
+		OnEnter(func(*craft.Fill) error { log = append(log, "enter"); return nil }).
+		OnLeave(func(*craft.Fill) error { log = append(log, "leave"); return nil }).
+		OnMove(func(*craft.Fill) error { log = append(log, "move"); return nil })
+	root := craft.NewHorizontalStack(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), h)
+
+	tests := []struct {
+		x       int
+		hovered bool
+		want    []string
+	}{
+		{5, false, []string{}},
+		{15, true, []string{"enter", "move"}},
+		{16, true, []string{"move"}},
+		{16, true, []string{}},
+		{5, false, []string{"leave"}},
+	}
+
+	in := input.New()
+	d := event.NewDispatcher()
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			log = []string{}
+			in.Update(input.State{Cursor: types.Position{X: tt.x, Y: 5}})
+			if err := d.Dispatch(root, in); err != nil {
+				t.Fatal(err)
+			}
+
+			if h.Hovered() != tt.hovered {
+				t.Errorf("Hovered should return %v, but got %v", tt.hovered, h.Hovered())
+			}
+			if fmt.Sprint(log) != fmt.Sprint(tt.want) {
+				t.Errorf("handlers should be called %v, but got %v", tt.want, log)
+			}
+		})
+	}
+}
+
+func TestHoverCursorShape(t *testing.T) {
+	pressed := NewMousePressed(
+		NewHover(craft.NewFill(types.Size{X: 10, Y: 10}, color.White)),
+		ebiten.MouseButtonLeft,
+		func(*Hover[*craft.Fill]) error { return nil },
+	)
+	text := NewHover(craft.NewFill(types.Size{X: 10, Y: 10}, color.White)).SetCursor(ebiten.CursorShapeText)
+	root := craft.NewHorizontalStack(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), pressed, text)
+
+	tests := []struct {
+		x    int
+		want ebiten.CursorShapeType
+	}{
+		{5, ebiten.CursorShapeDefault},
+		{15, ebiten.CursorShapePointer},
+		{25, ebiten.CursorShapeText},
+	}
+
+	in := input.New()
+	d := event.NewDispatcher()
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			in.Update(input.State{Cursor: types.Position{X: tt.x, Y: 5}})
+			d.Dispatch(root, in)
+			if got := d.CursorShape(); got != tt.want {
+				t.Errorf("CursorShape should return %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,492 @@
+package action
+
+import (
+	"errors"
+	"image/color"
+	"slices"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Colors of the menus.
+var (
+	MenuBackground color.Color = color.RGBA{0x30, 0x30, 0x30, 0xff}
+	MenuHighlight  color.Color = color.RGBA{0x40, 0x80, 0xff, 0xff}
+	MenuDisabled   color.Color = color.RGBA{0x80, 0x80, 0x80, 0xff}
+)
+
+const (
+	menuPadding = 4
+	// menuGap is the space between the label and the accelerator of an item.
+	menuGap = 16
+)
+
+type MenuHandler func(*MenuItem) error
+
+// MenuItem is an entry of a Menu: a command, a checkable command, a submenu or a separator.
+type MenuItem struct {
+	label       string
+	accelerator *Combo
+	handler     MenuHandler
+	submenu     *Menu
+	checkable   bool
+	checked     bool
+	disabled    bool
+	separator   bool
+}
+
+func NewMenuItem(label string, handler MenuHandler) *MenuItem {
+	return &MenuItem{label: label, handler: handler}
+}
+
+// NewCheckItem returns an item checked and unchecked each time it is chosen,
+// before the handler is called.
+func NewCheckItem(label string, handler MenuHandler) *MenuItem {
+	return &MenuItem{label: label, handler: handler, checkable: true}
+}
+
+// NewSubmenu returns an item opening menu beside it.
+func NewSubmenu(label string, menu *Menu) *MenuItem {
+	return &MenuItem{label: label, submenu: menu}
+}
+
+// NewSeparator returns a line between groups of items.
+func NewSeparator() *MenuItem {
+	return &MenuItem{separator: true}
+}
+
+// SetAccelerator sets the combination choosing the item without opening the menu.
+// It is shown beside the label, and works while the menu bar or the craft of the
+// context menu contains the focus.
+func (i *MenuItem) SetAccelerator(c Combo) *MenuItem {
+	i.accelerator = &c
+	return i
+}
+
+func (i *MenuItem) Accelerator() (Combo, bool) {
+	if i.accelerator == nil {
+		return Combo{}, false
+	}
+	return *i.accelerator, true
+}
+
+// SetChecked checks or unchecks a checkable item without calling the handler.
+func (i *MenuItem) SetChecked(checked bool) *MenuItem {
+	i.checked = checked
+	return i
+}
+
+func (i *MenuItem) Checked() bool {
+	return i.checked
+}
+
+func (i *MenuItem) SetDisabled(disabled bool) *MenuItem {
+	i.disabled = disabled
+	return i
+}
+
+func (i *MenuItem) Disabled() bool {
+	return i.disabled
+}
+
+func (i *MenuItem) Label() string {
+	return i.label
+}
+
+func (i *MenuItem) Submenu() *Menu {
+	return i.submenu
+}
+
+// selectable reports whether the item can be chosen or opened.
+func (i *MenuItem) selectable() bool {
+	return !i.separator && !i.disabled
+}
+
+// trigger flips a checkable item and calls the handler.
+func (i *MenuItem) trigger() error {
+	if i.checkable {
+		i.checked = !i.checked
+	}
+	if i.handler == nil {
+		return nil
+	}
+	return i.handler(i)
+}
+
+func (i *MenuItem) height() int {
+	if i.separator {
+		return menuPadding*2 + 1
+	}
+	return util.LineHeight() + menuPadding*2
+}
+
+// Menu is a column of items shown in an overlay,
+// opened by ContextMenu, MenuBar or Open.
+//
+// The arrow keys and the D-pad move through the items, wrapping around,
+// and open and close the submenus; going back closes the top menu.
+// Choosing an item closes every menu it was opened from, then calls its handler.
+//
+// ```
+// +-------------------+
+// |   Undo     Ctrl+Z |
+// +-------------------+
+// | v Grid            |
+// |   Zoom          > |
+// +-------------------+
+// ```
+type Menu struct {
+	items      []*MenuItem
+	color      color.Color
+	direction  types.Direction
+	overlay    *event.Overlay
+	dispatcher *event.Dispatcher
+	parent     *Menu
+	// step opens the menu delta menus away in a menu bar.
+	step func(delta int) error
+}
+
+func NewMenu(color color.Color, items ...*MenuItem) *Menu {
+	return &Menu{items: items, color: color}
+}
+
+func (m *Menu) Add(items ...*MenuItem) *Menu {
+	m.items = append(m.items, items...)
+	return m
+}
+
+func (m *Menu) Items() []*MenuItem {
+	return m.items
+}
+
+// SetDirection lays out the menu and its submenus right-to-left,
+// with the submenus opening to the left.
+func (m *Menu) SetDirection(d types.Direction) {
+	m.direction = d
+	for _, item := range m.items {
+		if item.submenu != nil {
+			item.submenu.SetDirection(d)
+		}
+	}
+}
+
+func (m *Menu) IsOpen() bool {
+	return m.overlay != nil && slices.Contains(m.dispatcher.Overlays(), m.overlay)
+}
+
+// Open shows the menu at p and focuses its first item.
+func (m *Menu) Open(d *event.Dispatcher, p types.Position) error {
+	return m.open(d, p, nil, nil)
+}
+
+func (m *Menu) open(d *event.Dispatcher, p types.Position, owner event.Target, parent *Menu) error {
+	if d == nil || m.IsOpen() {
+		return nil
+	}
+	m.dispatcher, m.parent = d, parent
+	m.overlay = &event.Overlay{Target: newMenuPanel(m), Position: p, Owner: owner}
+	return d.Open(m.overlay)
+}
+
+// Close closes the menu and its open submenus.
+func (m *Menu) Close() error {
+	if !m.IsOpen() {
+		return nil
+	}
+	return m.dispatcher.Close(m.overlay)
+}
+
+// root is the menu the submenus down to m were opened from.
+func (m *Menu) root() *Menu {
+	for m.parent != nil {
+		m = m.parent
+	}
+	return m
+}
+
+// choose closes the menus down to m and triggers item.
+func (m *Menu) choose(item *MenuItem) error {
+	return errors.Join(m.root().Close(), item.trigger())
+}
+
+// openSubmenu opens the submenu of r beside it, closing the other submenus of m.
+func (m *Menu) openSubmenu(r *menuRow) error {
+	sub := r.item.submenu
+	if err := m.closeSubmenus(sub); err != nil || sub.IsOpen() {
+		return err
+	}
+	p := m.overlay.Position.Add(types.Position{X: m.Size().X, Y: r.y - menuPadding})
+	if m.direction == types.RightToLeft {
+		p.X = m.overlay.Position.X - sub.Size().X
+	}
+	return sub.open(m.dispatcher, p, r, m)
+}
+
+// closeSubmenus closes the open submenus of m other than except.
+func (m *Menu) closeSubmenus(except *Menu) (err error) {
+	for _, item := range m.items {
+		if item.submenu != nil && item.submenu != except {
+			err = errors.Join(err, item.submenu.Close())
+		}
+	}
+	return err
+}
+
+// accelerate chooses the enabled item of m or its submenus whose accelerator matches e.
+func (m *Menu) accelerate(e *event.Event) (bool, error) {
+	for _, item := range m.items {
+		if item.disabled {
+			continue
+		}
+		if item.submenu != nil {
+			if ok, err := item.submenu.accelerate(e); ok {
+				return true, err
+			}
+			continue
+		}
+		if item.accelerator != nil && item.accelerator.Match(e) {
+			return true, item.trigger()
+		}
+	}
+	return false, nil
+}
+
+// columns returns the widths of the check mark, the label, the accelerator
+// and the submenu arrow columns, zero for the columns no item uses.
+func (m *Menu) columns() (check, label, accelerator, arrow int) {
+	for _, item := range m.items {
+		label = max(label, util.TextWidth(item.label))
+		if item.checkable {
+			check = util.LineHeight()
+		}
+		if c, ok := item.Accelerator(); ok {
+			accelerator = max(accelerator, menuGap+util.TextWidth(c.String()))
+		}
+		if item.submenu != nil {
+			arrow = menuGap
+		}
+	}
+	return check, label, accelerator, arrow
+}
+
+// Size is the size of the menu when it is open.
+func (m *Menu) Size() types.Size {
+	check, label, accelerator, arrow := m.columns()
+	size := types.Size{X: menuPadding*2 + check + label + accelerator + arrow, Y: menuPadding * 2}
+	for _, item := range m.items {
+		size.Y += item.height()
+	}
+	return size
+}
+
+// menuPanel is the craft of an open menu.
+type menuPanel struct {
+	menu *Menu
+	rows []*menuRow
+}
+
+func newMenuPanel(m *Menu) *menuPanel {
+	p := &menuPanel{menu: m}
+	y := menuPadding
+	for _, item := range m.items {
+		p.rows = append(p.rows, &menuRow{menu: m, item: item, y: y})
+		y += item.height()
+	}
+	return p
+}
+
+func (p *menuPanel) Size() types.Size {
+	return p.menu.Size()
+}
+
+func (p *menuPanel) Children() []event.Child {
+	children := make([]event.Child, len(p.rows))
+	for i, r := range p.rows {
+		children[i] = event.Child{Target: r, Position: types.Position{Y: r.y}}
+	}
+	return children
+}
+
+func (p *menuPanel) Image() *ebiten.Image {
+	m := p.menu
+	size := m.Size()
+	image := ebiten.NewImage(size.X, size.Y)
+	image.Fill(MenuBackground)
+	vector.StrokeRect(image, 0.5, 0.5, float32(size.X)-1, float32(size.Y)-1, 1, MenuDisabled, false)
+
+	check, _, _, _ := m.columns()
+	// mirror flips x coordinates of a width w when right-to-left.
+	mirror := func(x, w int) int {
+		if m.direction == types.RightToLeft {
+			return size.X - x - w
+		}
+		return x
+	}
+	for _, r := range p.rows {
+		item := r.item
+		h := item.height()
+		if item.separator {
+			y := float32(r.y + h/2)
+			vector.StrokeLine(image, menuPadding, y, float32(size.X-menuPadding), y, 1, MenuDisabled, false)
+			continue
+		}
+		if item.selectable() && (r.hovered || (m.dispatcher != nil && m.dispatcher.Focused() == r)) {
+			vector.DrawFilledRect(image, 0, float32(r.y), float32(size.X), float32(h), MenuHighlight, false)
+		}
+
+		clr := m.color
+		if item.disabled {
+			clr = MenuDisabled
+		}
+		y := r.y + menuPadding
+		if item.checked {
+			s := float32(util.LineHeight())
+			x := float32(mirror(menuPadding, check))
+			vector.StrokeLine(image, x+s*0.2, float32(y)+s*0.5, x+s*0.4, float32(y)+s*0.75, 2, clr, true)
+			vector.StrokeLine(image, x+s*0.4, float32(y)+s*0.75, x+s*0.8, float32(y)+s*0.25, 2, clr, true)
+		}
+		w := util.TextWidth(item.label)
+		util.DrawTextAt(image, item.label, clr, types.Position{X: mirror(menuPadding+check, w), Y: y})
+		if c, ok := item.Accelerator(); ok {
+			str := c.String()
+			w := util.TextWidth(str)
+			x := size.X - menuPadding - w
+			if item.submenu == nil {
+				x = mirror(x, w)
+			}
+			util.DrawTextAt(image, str, clr, types.Position{X: x, Y: y})
+		}
+		if item.submenu != nil {
+			arrow := ">"
+			if m.direction == types.RightToLeft {
+				arrow = "<"
+			}
+			w := util.TextWidth(arrow)
+			util.DrawTextAt(image, arrow, clr, types.Position{X: mirror(size.X-menuPadding-w, w), Y: y})
+		}
+	}
+	return image
+}
+
+// HandleEvent moves through the items with the keys and gamepad buttons the rows let through.
+func (p *menuPanel) HandleEvent(e *event.Event) error {
+	if e.Phase == event.Capture || (e.Type != event.KeyDown && e.Type != event.GamepadDown) {
+		return nil
+	}
+	m, actions := p.menu, e.Dispatcher().Actions()
+	forward, backward := input.ActionRight, input.ActionLeft
+	if m.direction == types.RightToLeft {
+		forward, backward = backward, forward
+	}
+
+	var err error
+	switch {
+	case e.Is(actions, input.ActionDown):
+		err = p.move(e.Dispatcher(), 1)
+	case e.Is(actions, input.ActionUp):
+		err = p.move(e.Dispatcher(), -1)
+	case e.Is(actions, forward):
+		if r, ok := e.Target().(*menuRow); ok && r.item.submenu != nil && r.item.selectable() {
+			err = m.openSubmenu(r)
+		} else if root := m.root(); root.step != nil {
+			err = root.step(1)
+		}
+	case e.Is(actions, backward):
+		switch {
+		case m.parent != nil:
+			err = m.Close()
+		case m.step != nil:
+			err = m.step(-1)
+		}
+	default:
+		return nil
+	}
+	e.StopPropagation()
+	return err
+}
+
+// move focuses the selectable row delta rows away from the focused one, wrapping around.
+func (p *menuPanel) move(d *event.Dispatcher, delta int) error {
+	n := len(p.rows)
+	i := slices.IndexFunc(p.rows, func(r *menuRow) bool { return r == d.Focused() })
+	if i < 0 && delta < 0 {
+		i = 0
+	}
+	for range n {
+		i = (i + delta + n) % n
+		if p.rows[i].item.selectable() {
+			return d.SetFocus(p.rows[i])
+		}
+	}
+	return nil
+}
+
+// menuRow is an item of an open menu.
+type menuRow struct {
+	menu    *Menu
+	item    *MenuItem
+	y       int
+	hovered bool
+	pressed bool
+}
+
+func (r *menuRow) Size() types.Size {
+	return types.Size{X: r.menu.Size().X, Y: r.item.height()}
+}
+
+func (r *menuRow) Focusable() bool {
+	return r.item.selectable()
+}
+
+func (r *menuRow) CursorShape() ebiten.CursorShapeType {
+	if r.item.disabled {
+		return ebiten.CursorShapeNotAllowed
+	}
+	return ebiten.CursorShapeDefault
+}
+
+// activate opens the submenu of the row, or chooses its item.
+func (r *menuRow) activate() error {
+	if !r.item.selectable() {
+		return nil
+	}
+	if r.item.submenu != nil {
+		return r.menu.openSubmenu(r)
+	}
+	return r.menu.choose(r.item)
+}
+
+func (r *menuRow) HandleEvent(e *event.Event) error {
+	switch e.Type {
+	case event.PointerEnter:
+		r.hovered = true
+		// Pointing at an item opens its submenu and closes the others.
+		if r.item.submenu != nil && r.item.selectable() {
+			return r.menu.openSubmenu(r)
+		}
+		return r.menu.closeSubmenus(nil)
+	case event.PointerLeave:
+		r.hovered = false
+	case event.PointerDown:
+		r.pressed = true
+		e.StopPropagation()
+	case event.PointerUp:
+		if !r.pressed {
+			return nil
+		}
+		r.pressed = false
+		e.StopPropagation()
+		if e.Inside() {
+			return r.activate()
+		}
+	case event.Activate:
+		e.StopPropagation()
+		return r.activate()
+	}
+	return nil
+}
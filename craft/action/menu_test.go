@@ -0,0 +1,221 @@
+package action
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ craft.Craft = NewContextMenu(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), NewMenu(color.White))
+var _ craft.Craft = NewMenuBar(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), color.White)
+
+// testMenu returns a menu and a log of the items chosen.
+func testMenu() (*Menu, *[]string) {
+	log := []string{}
+	handler := func(i *MenuItem) error { log = append(log, i.Label()); return nil }
+	return NewMenu(color.White,
+		NewMenuItem("Cut", handler).SetAccelerator(Combo{ebiten.KeyX, event.Control}),
+		NewSeparator(),
+		NewCheckItem("Grid", handler),
+		NewMenuItem("Paste", handler).SetDisabled(true).SetAccelerator(Combo{ebiten.KeyV, event.Control}),
+		NewSubmenu("Zoom", NewMenu(color.White,
+			NewMenuItem("In", handler).SetAccelerator(Combo{ebiten.KeyEqual, event.Control}),
+			NewMenuItem("Out", handler),
+		)),
+	), &log
+}
+
+func label(target event.Target) string {
+	if r, ok := target.(*menuRow); ok {
+		return r.item.Label()
+	}
+	return fmt.Sprint(target)
+}
+
+func TestContextMenuKeys(t *testing.T) {
+	menu, log := testMenu()
+	button := craft.NewButton(craft.NewFill(types.Size{X: 100, Y: 100}, color.White), nil)
+	root := NewContextMenu(button, menu)
+	in := input.New()
+	d := event.NewDispatcher()
+	press := func(keys ...ebiten.Key) {
+		in.Update(input.State{Keys: keys})
+		d.Dispatch(root, in)
+		in.Update(input.State{})
+		d.Dispatch(root, in)
+	}
+
+	press(ebiten.KeyTab)
+	press(ebiten.KeyControl, ebiten.KeyX)
+	press(ebiten.KeyControl, ebiten.KeyV)
+	press(ebiten.KeyControl, ebiten.KeyEqual)
+	if fmt.Sprint(*log) != "[Cut In]" {
+		t.Errorf("the accelerators should choose [Cut In], but got %v", *log)
+	}
+
+	zoom := menu.items[4].submenu
+	tests := []struct {
+		keys  []ebiten.Key
+		focus string
+		open  []*Menu
+	}{
+		{[]ebiten.Key{ebiten.KeyContextMenu}, "Cut", []*Menu{menu}},
+		{[]ebiten.Key{ebiten.KeyArrowDown}, "Grid", []*Menu{menu}},
+		{[]ebiten.Key{ebiten.KeyArrowDown}, "Zoom", []*Menu{menu}},
+		{[]ebiten.Key{ebiten.KeyArrowRight}, "In", []*Menu{menu, zoom}},
+		{[]ebiten.Key{ebiten.KeyArrowUp}, "Out", []*Menu{menu, zoom}},
+		{[]ebiten.Key{ebiten.KeyArrowLeft}, "Zoom", []*Menu{menu}},
+		{[]ebiten.Key{ebiten.KeyArrowDown}, "Cut", []*Menu{menu}},
+		{[]ebiten.Key{ebiten.KeyArrowUp}, "Zoom", []*Menu{menu}},
+		{[]ebiten.Key{ebiten.KeyArrowRight}, "In", []*Menu{menu, zoom}},
+		{[]ebiten.Key{ebiten.KeyEscape}, "Zoom", []*Menu{menu}},
+		{[]ebiten.Key{ebiten.KeyArrowUp}, "Grid", []*Menu{menu}},
+		{[]ebiten.Key{ebiten.KeyEnter}, fmt.Sprint(button), []*Menu{}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			press(tt.keys...)
+			if got := label(d.Focused()); got != tt.focus {
+				t.Errorf("Focused should return %s, but got %s", tt.focus, got)
+			}
+			for _, m := range []*Menu{menu, zoom} {
+				want := false
+				for _, o := range tt.open {
+					want = want || o == m
+				}
+				if m.IsOpen() != want {
+					t.Errorf("IsOpen should return %v, but got %v", want, m.IsOpen())
+				}
+			}
+		})
+	}
+
+	if !menu.items[2].Checked() || fmt.Sprint(*log) != "[Cut In Grid]" {
+		t.Errorf("Grid should be checked and chosen, but got %v", *log)
+	}
+}
+
+func TestContextMenuPointer(t *testing.T) {
+	menu, log := testMenu()
+	root := craft.NewHorizontalStack(
+		craft.NewFill(types.Size{X: 100, Y: 200}, color.White),
+		NewContextMenu(craft.NewFill(types.Size{X: 200, Y: 200}, color.White), menu),
+	)
+	in := input.New()
+	d := event.NewDispatcher()
+	click := func(p types.Position, b ebiten.MouseButton) {
+		in.Update(input.State{Cursor: p, Buttons: []ebiten.MouseButton{b}})
+		d.Dispatch(root, in)
+		in.Update(input.State{Cursor: p})
+		d.Dispatch(root, in)
+	}
+
+	row := util.LineHeight() + menuPadding*2
+	// the rows of the menu opened at (110, 10)
+	cut := types.Position{X: 115, Y: 10 + menuPadding + row/2}
+	grid := cut.Add(types.Position{Y: row + menuPadding*2 + 1})
+	paste := grid.Add(types.Position{Y: row})
+
+	tests := []struct {
+		position types.Position
+		button   ebiten.MouseButton
+		open     bool
+		want     string
+	}{
+		{types.Position{X: 50, Y: 10}, ebiten.MouseButtonRight, false, "[]"},
+		{types.Position{X: 110, Y: 10}, ebiten.MouseButtonRight, true, "[]"},
+		{paste, ebiten.MouseButtonLeft, true, "[]"},
+		{grid, ebiten.MouseButtonLeft, false, "[Grid]"},
+		{types.Position{X: 110, Y: 10}, ebiten.MouseButtonRight, true, "[Grid]"},
+		{types.Position{X: 50, Y: 10}, ebiten.MouseButtonLeft, false, "[Grid]"},
+		{types.Position{X: 110, Y: 10}, ebiten.MouseButtonRight, true, "[Grid]"},
+		{cut, ebiten.MouseButtonLeft, false, "[Grid Cut]"},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			click(tt.position, tt.button)
+			if menu.IsOpen() != tt.open {
+				t.Errorf("IsOpen should return %v, but got %v", tt.open, menu.IsOpen())
+			}
+			if fmt.Sprint(*log) != tt.want {
+				t.Errorf("the items chosen should be %s, but got %v", tt.want, *log)
+			}
+		})
+	}
+}
+
+func TestMenuBar(t *testing.T) {
+	edit, log := testMenu()
+	file := NewMenu(color.White, NewMenuItem("Save", nil).SetAccelerator(Combo{ebiten.KeyS, event.Control}))
+	bar := NewMenuBar(craft.NewButton(craft.NewFill(types.Size{X: 200, Y: 200}, color.White), nil), color.White).
+		Add("File", file).
+		Add("Edit", edit)
+	in := input.New()
+	d := event.NewDispatcher()
+	tick := func(s input.State) {
+		in.Update(s)
+		d.Dispatch(bar, in)
+		bar.Update(types.Position{})
+	}
+	press := func(keys ...ebiten.Key) {
+		tick(input.State{Keys: keys})
+		tick(input.State{})
+	}
+
+	if want := (types.Size{X: 200, Y: 200 + menuBarHeight()}); bar.Size() != want {
+		t.Errorf("Size should return %v, but got %v", want, bar.Size())
+	}
+
+	fileTitle := types.Position{X: 2, Y: 2}
+	editTitle := types.Position{X: bar.titles[0].Size().X + 2, Y: 2}
+	tests := []struct {
+		do   func()
+		open []bool
+	}{
+		{func() { press(ebiten.KeyF10) }, []bool{true, false}},
+		{func() { press(ebiten.KeyArrowRight) }, []bool{false, true}},
+		{func() { press(ebiten.KeyArrowRight) }, []bool{true, false}},
+		{func() { press(ebiten.KeyArrowLeft) }, []bool{false, true}},
+		{func() { press(ebiten.KeyEscape) }, []bool{false, false}},
+		{func() {
+			tick(input.State{Cursor: fileTitle, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}})
+			tick(input.State{Cursor: fileTitle})
+		}, []bool{true, false}},
+		{func() { tick(input.State{Cursor: editTitle}) }, []bool{false, true}},
+		{func() {
+			tick(input.State{Cursor: editTitle, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}})
+			tick(input.State{Cursor: editTitle})
+		}, []bool{false, false}},
+		{func() { tick(input.State{Cursor: fileTitle}) }, []bool{false, false}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			tt.do()
+			for j, m := range []*Menu{file, edit} {
+				if m.IsOpen() != tt.open[j] {
+					t.Errorf("IsOpen of the menu %d should return %v, but got %v", j+1, tt.open[j], m.IsOpen())
+				}
+			}
+		})
+	}
+
+	if p := edit.overlay.Position; p != (types.Position{X: editTitle.X - 2, Y: menuBarHeight()}) {
+		t.Errorf("the menu should open below its title, but got %v", p)
+	}
+
+	press(ebiten.KeyTab)
+	press(ebiten.KeyControl, ebiten.KeyX)
+	if fmt.Sprint(*log) != "[Cut]" {
+		t.Errorf("the accelerators should choose [Cut], but got %v", *log)
+	}
+}
@@ -0,0 +1,285 @@
+package action
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// ContextMenu
+//
+// ContextMenu opens the menu at the pointer when the right button is pressed on the wrapped craft,
+// or at the craft when the context menu key or Shift+F10 is pressed while it contains the focus.
+// The accelerators of the menu work while it contains the focus as well.
+// ContextMenu is transparent like MousePressed.
+type ContextMenu[T craft.Craft] struct {
+	wrapper[T]
+	menu *Menu
+}
+
+func NewContextMenu[T craft.Craft](craft T, menu *Menu) *ContextMenu[T] {
+	return &ContextMenu[T]{wrapper[T]{craft}, menu}
+}
+
+func (c *ContextMenu[T]) Menu() *Menu {
+	return c.menu
+}
+
+func (c *ContextMenu[T]) AddText(str string, color color.Color) craft.Self {
+	c.craft.AddText(str, color)
+	return c
+}
+
+func (c *ContextMenu[T]) SetText(str string, color color.Color) craft.Self {
+	c.craft.SetText(str, color)
+	return c
+}
+
+func (c *ContextMenu[T]) ClearText() craft.Self {
+	c.craft.ClearText()
+	return c
+}
+
+func (c *ContextMenu[T]) SetDirection(d types.Direction) {
+	c.wrapper.SetDirection(d)
+	c.menu.SetDirection(d)
+}
+
+func (c *ContextMenu[T]) HandleEvent(e *event.Event) error {
+	if e.Phase == event.Capture {
+		return nil
+	}
+	switch e.Type {
+	case event.PointerDown:
+		if e.Button != ebiten.MouseButtonRight || e.Touch {
+			return nil
+		}
+		e.StopPropagation()
+		err := c.menu.Close()
+		return errors.Join(err, c.menu.Open(e.Dispatcher(), e.Position))
+	case event.KeyDown:
+		if e.Key == ebiten.KeyContextMenu || (e.Key == ebiten.KeyF10 && e.Modifiers == event.Shift) {
+			e.StopPropagation()
+			return c.menu.Open(e.Dispatcher(), e.Position.Sub(e.Local()))
+		}
+		ok, err := c.menu.accelerate(e)
+		if ok {
+			e.StopPropagation()
+		}
+		return err
+	}
+	return nil
+}
+
+// menuTitle is the title of a menu in a MenuBar.
+type menuTitle struct {
+	title string
+	menu  *Menu
+	open  func(e *event.Event) error
+	hover func() error
+}
+
+func (t *menuTitle) Size() types.Size {
+	return types.Size{X: util.TextWidth(t.title) + menuPadding*4, Y: menuBarHeight()}
+}
+
+func (t *menuTitle) HandleEvent(e *event.Event) error {
+	switch e.Type {
+	case event.PointerDown:
+		if e.Button != ebiten.MouseButtonLeft {
+			return nil
+		}
+		e.StopPropagation()
+		if t.menu.IsOpen() {
+			return t.menu.Close()
+		}
+		return t.open(e)
+	case event.PointerEnter:
+		return t.hover()
+	}
+	return nil
+}
+
+func menuBarHeight() int {
+	return util.LineHeight() + menuPadding*2
+}
+
+// MenuBar
+//
+// MenuBar draws a row of menu titles above the wrapped craft, usually the root of a scene.
+// Pressing a title opens its menu below it, and while a menu is open,
+// pointing at another title opens that one instead.
+// F10 opens the first menu, and the left and right keys move between the menus.
+// The accelerators of every menu work while the focus is in the wrapped craft.
+//
+// ```
+// +------+------+-------------+
+// | File | Edit |             |
+// +------+------+-------------+
+// |                           |
+// |           craft           |
+// |                           |
+// +---------------------------+
+// ```
+type MenuBar[T craft.Craft] struct {
+	craft     T
+	titles    []*menuTitle
+	color     color.Color
+	position  types.Position
+	direction types.Direction
+}
+
+func NewMenuBar[T craft.Craft](craft T, color color.Color) *MenuBar[T] {
+	return &MenuBar[T]{craft: craft, color: color}
+}
+
+// Add adds a menu titled title at the end of the bar.
+func (b *MenuBar[T]) Add(title string, menu *Menu) *MenuBar[T] {
+	i := len(b.titles)
+	menu.SetDirection(b.direction)
+	b.titles = append(b.titles, &menuTitle{
+		title: title,
+		menu:  menu,
+		open:  func(e *event.Event) error { return b.open(e.Dispatcher(), i) },
+		hover: func() error {
+			for _, t := range b.titles {
+				if t.menu != menu && t.menu.IsOpen() {
+					return errors.Join(t.menu.Close(), b.open(t.menu.dispatcher, i))
+				}
+			}
+			return nil
+		},
+	})
+	return b
+}
+
+func (b *MenuBar[T]) Menus() []*Menu {
+	menus := make([]*Menu, len(b.titles))
+	for i, t := range b.titles {
+		menus[i] = t.menu
+	}
+	return menus
+}
+
+func (b *MenuBar[T]) Craft() T {
+	return b.craft
+}
+
+// open opens the menu i below its title.
+func (b *MenuBar[T]) open(d *event.Dispatcher, i int) error {
+	n := len(b.titles)
+	t := b.titles[i]
+	t.menu.step = func(delta int) error {
+		return errors.Join(t.menu.Close(), b.open(d, (i+delta+n)%n))
+	}
+	p := b.position.Add(types.Position{X: b.titleX(i), Y: menuBarHeight()})
+	if b.direction == types.RightToLeft {
+		p.X += t.Size().X - t.menu.Size().X
+	}
+	return t.menu.open(d, p, t, nil)
+}
+
+// titleX returns the x coordinate of the title i, mirrored when right-to-left.
+func (b *MenuBar[T]) titleX(i int) int {
+	x := 0
+	for _, t := range b.titles[:i] {
+		x += t.Size().X
+	}
+	if b.direction == types.RightToLeft {
+		return b.Size().X - x - b.titles[i].Size().X
+	}
+	return x
+}
+
+func (b *MenuBar[T]) Image() *ebiten.Image {
+	size := b.Size()
+	image := ebiten.NewImage(size.X, size.Y)
+	h := menuBarHeight()
+	vector.DrawFilledRect(image, 0, 0, float32(size.X), float32(h), MenuBackground, false)
+	for i, t := range b.titles {
+		x := b.titleX(i)
+		if t.menu.IsOpen() {
+			vector.DrawFilledRect(image, float32(x), 0, float32(t.Size().X), float32(h), MenuHighlight, false)
+		}
+		util.DrawTextAt(image, t.title, b.color, types.Position{X: x + menuPadding*2, Y: menuPadding})
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(0, float64(h))
+	image.DrawImage(b.craft.Image(), op)
+	return image
+}
+
+func (b *MenuBar[T]) Size() types.Size {
+	size := b.craft.Size()
+	width := 0
+	for _, t := range b.titles {
+		width += t.Size().X
+	}
+	return types.Size{X: max(size.X, width), Y: size.Y + menuBarHeight()}
+}
+
+// Children are the titles and the wrapped craft below them.
+func (b *MenuBar[T]) Children() []event.Child {
+	children := make([]event.Child, 0, len(b.titles)+1)
+	for i, t := range b.titles {
+		children = append(children, event.Child{Target: t, Position: types.Position{X: b.titleX(i)}})
+	}
+	return append(children, event.Child{Target: b.craft, Position: types.Position{Y: menuBarHeight()}})
+}
+
+func (b *MenuBar[T]) AddText(str string, color color.Color) craft.Self {
+	b.craft.AddText(str, color)
+	return b
+}
+
+func (b *MenuBar[T]) SetText(str string, color color.Color) craft.Self {
+	b.craft.SetText(str, color)
+	return b
+}
+
+func (b *MenuBar[T]) ClearText() craft.Self {
+	b.craft.ClearText()
+	return b
+}
+
+func (b *MenuBar[T]) SetDirection(d types.Direction) {
+	b.direction = d
+	craft.SetDirection(b.craft, d)
+	for _, t := range b.titles {
+		t.menu.SetDirection(d)
+	}
+}
+
+func (b *MenuBar[T]) Const() *craft.Image {
+	return craft.NewImage(b.Image())
+}
+
+// Update remembers where the bar is, to open the menus below their titles.
+func (b *MenuBar[T]) Update(p types.Position) error {
+	b.position = p
+	return b.craft.Update(p.Add(types.Position{Y: menuBarHeight()}))
+}
+
+func (b *MenuBar[T]) HandleEvent(e *event.Event) error {
+	if e.Phase == event.Capture || e.Type != event.KeyDown {
+		return nil
+	}
+	if e.Key == ebiten.KeyF10 && e.Modifiers == 0 && !e.Repeat && len(b.titles) > 0 {
+		e.StopPropagation()
+		return b.open(e.Dispatcher(), 0)
+	}
+	for _, t := range b.titles {
+		if ok, err := t.menu.accelerate(e); ok {
+			e.StopPropagation()
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package action
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Combo is a key pressed with exactly the given modifiers, such as Ctrl+S.
+type Combo struct {
+	Key       ebiten.Key
+	Modifiers event.Modifier
+}
+
+// Match reports whether e is a KeyDown of the combination, repeats excluded.
+func (c Combo) Match(e *event.Event) bool {
+	return e.Type == event.KeyDown && !e.Repeat && e.Key == c.Key && e.Modifiers == c.Modifiers
+}
+
+// String is the combination as shown in menus, such as "Ctrl+Shift+S".
+func (c Combo) String() string {
+	var b strings.Builder
+	for _, m := range []struct {
+		modifier event.Modifier
+		name     string
+	}{{event.Control, "Ctrl+"}, {event.Alt, "Alt+"}, {event.Shift, "Shift+"}, {event.Meta, "Meta+"}} {
+		if c.Modifiers&m.modifier != 0 {
+			b.WriteString(m.name)
+		}
+	}
+	b.WriteString(c.Key.String())
+	return b.String()
+}
+
+// Shortcut
+//
+// Shortcut calls the handler when the combination is pressed
+// while the wrapped craft or a craft in it is focused.
+// Use Shortcuts on the root for shortcuts of the whole scene.
+type Shortcut[T craft.Craft] struct {
+	wrapper[T]
+	combo   Combo
+	handler ActionHandler[T]
+}
+
+func NewShortcut[T craft.Craft](craft T, combo Combo, handler ActionHandler[T]) *Shortcut[T] {
+	return &Shortcut[T]{wrapper[T]{craft}, combo, handler}
+}
+
+func (s *Shortcut[T]) AddText(str string, color color.Color) craft.Self {
+	s.craft.AddText(str, color)
+	return s
+}
+
+func (s *Shortcut[T]) SetText(str string, color color.Color) craft.Self {
+	s.craft.SetText(str, color)
+	return s
+}
+
+func (s *Shortcut[T]) ClearText() craft.Self {
+	s.craft.ClearText()
+	return s
+}
+
+// HandleEvent calls the handler once the focused craft let the combination through.
+func (s *Shortcut[T]) HandleEvent(e *event.Event) error {
+	if e.Phase == event.Capture || !s.combo.Match(e) {
+		return nil
+	}
+	e.StopPropagation()
+	return call(s.handler, s.craft)
+}
+
+// Shortcuts
+//
+// Shortcuts is a registry of the shortcuts of a scene, wrapping its root.
+// Keys go to the focused craft first, so a focused text input swallows the keys it types
+// and the shortcuts it handles itself, such as Ctrl+C.
+type Shortcuts[T craft.Craft] struct {
+	wrapper[T]
+	handlers map[Combo]ActionHandler[T]
+}
+
+func NewShortcuts[T craft.Craft](craft T) *Shortcuts[T] {
+	return &Shortcuts[T]{wrapper[T]{craft}, map[Combo]ActionHandler[T]{}}
+}
+
+// Add registers handler for combo, replacing the previous one.
+func (s *Shortcuts[T]) Add(combo Combo, handler ActionHandler[T]) *Shortcuts[T] {
+	s.handlers[combo] = handler
+	return s
+}
+
+func (s *Shortcuts[T]) Remove(combo Combo) *Shortcuts[T] {
+	delete(s.handlers, combo)
+	return s
+}
+
+func (s *Shortcuts[T]) Has(combo Combo) bool {
+	_, ok := s.handlers[combo]
+	return ok
+}
+
+func (s *Shortcuts[T]) AddText(str string, color color.Color) craft.Self {
+	s.craft.AddText(str, color)
+	return s
+}
+
+func (s *Shortcuts[T]) SetText(str string, color color.Color) craft.Self {
+	s.craft.SetText(str, color)
+	return s
+}
+
+func (s *Shortcuts[T]) ClearText() craft.Self {
+	s.craft.ClearText()
+	return s
+}
+
+func (s *Shortcuts[T]) HandleEvent(e *event.Event) error {
+	if e.Phase == event.Capture || e.Type != event.KeyDown || e.Repeat {
+		return nil
+	}
+	handler, ok := s.handlers[Combo{e.Key, e.Modifiers}]
+	if !ok {
+		return nil
+	}
+	e.StopPropagation()
+	return call(handler, s.craft)
+}
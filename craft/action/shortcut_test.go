@@ -0,0 +1,100 @@
+package action
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestComboString(t *testing.T) {
+	tests := []struct {
+		combo Combo
+		want  string
+	}{
+		{Combo{ebiten.KeyS, event.Control}, "Ctrl+S"},
+		{Combo{ebiten.KeyS, event.Shift | event.Control}, "Ctrl+Shift+S"},
+		{Combo{ebiten.KeyF5, 0}, "F5"},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			if got := tt.combo.String(); got != tt.want {
+				t.Errorf("String should return %q, but got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestShortcuts(t *testing.T) {
+	saved, searched := 0, 0
+	text := craft.NewTextInput(100, color.White)
+	root := NewShortcuts[craft.Craft](craft.NewVerticalStack(text, craft.NewFill(types.Size{X: 100, Y: 20}, color.White))).
+		Add(Combo{ebiten.KeyS, event.Control}, func(craft.Craft) error { saved++; return nil }).
+		Add(Combo{ebiten.KeyF, 0}, func(craft.Craft) error { searched++; return nil })
+
+	in := input.New()
+	d := event.NewDispatcher()
+	press := func(keys ...ebiten.Key) {
+		in.Update(input.State{Keys: keys})
+		d.Dispatch(root, in)
+		in.Update(input.State{})
+		d.Dispatch(root, in)
+	}
+
+	tests := []struct {
+		focus    event.Target
+		keys     []ebiten.Key
+		saved    int
+		searched int
+	}{
+		{nil, []ebiten.Key{ebiten.KeyF}, 0, 1},
+		{nil, []ebiten.Key{ebiten.KeyControl, ebiten.KeyS}, 1, 1},
+		{nil, []ebiten.Key{ebiten.KeyS}, 1, 1},
+		// the text input types F, but lets Ctrl+S through
+		{text, []ebiten.Key{ebiten.KeyF}, 1, 1},
+		{text, []ebiten.Key{ebiten.KeyControl, ebiten.KeyS}, 2, 1},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			d.SetFocus(tt.focus)
+			press(tt.keys...)
+			if saved != tt.saved || searched != tt.searched {
+				t.Errorf("handlers should be called %d and %d times, but got %d and %d", tt.saved, tt.searched, saved, searched)
+			}
+		})
+	}
+
+	root.Remove(Combo{ebiten.KeyF, 0})
+	if root.Has(Combo{ebiten.KeyF, 0}) {
+		t.Errorf("Has should return false after Remove, but got true")
+	}
+}
+
+func TestShortcut(t *testing.T) {
+	count := 0
+	inner := NewMousePressed(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), ebiten.MouseButtonLeft, func(*craft.Fill) error { return nil })
+	s := NewShortcut(inner, Combo{ebiten.KeyD, event.Control}, func(*MousePressed[*craft.Fill]) error { count++; return nil })
+	other := NewMousePressed(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), ebiten.MouseButtonLeft, func(*craft.Fill) error { return nil })
+	root := craft.NewHorizontalStack(s, other)
+
+	in := input.New()
+	d := event.NewDispatcher()
+	for _, focus := range []event.Target{other, inner} {
+		d.SetFocus(focus)
+		in.Update(input.State{Keys: []ebiten.Key{ebiten.KeyControl, ebiten.KeyD}})
+		d.Dispatch(root, in)
+		in.Update(input.State{})
+		d.Dispatch(root, in)
+	}
+
+	if count != 1 {
+		t.Errorf("handler should be called 1 time, but got %d", count)
+	}
+}
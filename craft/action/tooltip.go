@@ -0,0 +1,158 @@
+package action
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+)
+
+// DefaultTooltipDelay is the number of ticks the pointer rests on a craft before its tooltip shows.
+const DefaultTooltipDelay = 30
+
+// TooltipBackground is the color of the panel of text tooltips.
+var TooltipBackground color.Color = color.RGBA{0x30, 0x30, 0x30, 0xf0}
+
+const (
+	tooltipPadding = 4
+	// tooltipOffset keeps the tip clear of the cursor.
+	tooltipOffset = 16
+)
+
+// Tooltip
+//
+// Tooltip shows a tip in a passive overlay once the pointer has rested on the wrapped craft
+// for the delay, below and to the right of the cursor.
+// A tip that would leave the root is shown above or to the left of the cursor instead.
+// It hides when the pointer leaves the craft or presses it, and shows again only after
+// the pointer leaves and comes back.
+// Tooltip is transparent like MousePressed, and it counts the delay in Update.
+type Tooltip[T craft.Craft] struct {
+	wrapper[T]
+	tip        craft.Craft
+	delay      int
+	ticks      int
+	hovered    bool
+	pressed    bool
+	cursor     types.Position
+	overlay    *event.Overlay
+	dispatcher *event.Dispatcher
+}
+
+func NewTooltip[T craft.Craft](c T, tip craft.Craft) *Tooltip[T] {
+	return &Tooltip[T]{wrapper: wrapper[T]{c}, tip: tip, delay: DefaultTooltipDelay}
+}
+
+// NewTextTooltip returns a Tooltip showing str on a small panel.
+func NewTextTooltip[T craft.Craft](c T, str string, color color.Color) *Tooltip[T] {
+	return NewTooltip(c, textPanel(str, color))
+}
+
+func textPanel(str string, clr color.Color) craft.Craft {
+	text := types.Size{X: util.TextWidth(str), Y: util.LineHeight()}
+	margin := types.MarginAll(tooltipPadding)
+	return craft.NewLayer(
+		craft.NewFill(util.CalcSize(text, margin), TooltipBackground),
+		craft.NewBox(craft.NewFill(text, color.Transparent).SetText(str, clr), margin),
+	)
+}
+
+// SetDelay sets the number of ticks before the tip shows.
+func (t *Tooltip[T]) SetDelay(ticks int) *Tooltip[T] {
+	t.delay = ticks
+	return t
+}
+
+// SetTip replaces the tip, taking effect the next time it shows.
+func (t *Tooltip[T]) SetTip(tip craft.Craft) *Tooltip[T] {
+	t.tip = tip
+	return t
+}
+
+func (t *Tooltip[T]) Tip() craft.Craft {
+	return t.tip
+}
+
+// Shown reports whether the tip is shown.
+func (t *Tooltip[T]) Shown() bool {
+	return t.overlay != nil
+}
+
+func (t *Tooltip[T]) AddText(str string, color color.Color) craft.Self {
+	t.craft.AddText(str, color)
+	return t
+}
+
+func (t *Tooltip[T]) SetText(str string, color color.Color) craft.Self {
+	t.craft.SetText(str, color)
+	return t
+}
+
+func (t *Tooltip[T]) ClearText() craft.Self {
+	t.craft.ClearText()
+	return t
+}
+
+func (t *Tooltip[T]) Update(p types.Position) error {
+	err := t.craft.Update(p)
+	if !t.hovered || t.pressed || t.overlay != nil || t.tip == nil {
+		return err
+	}
+	t.ticks++
+	if t.ticks >= t.delay {
+		err = errors.Join(err, t.show())
+	}
+	return err
+}
+
+// show opens the tip near the cursor, flipped to the other side of it where it would not fit.
+func (t *Tooltip[T]) show() error {
+	if t.dispatcher == nil {
+		return nil
+	}
+	root, size := t.dispatcher.RootSize(), t.tip.Size()
+	p := t.cursor.Add(types.Position{X: tooltipOffset, Y: tooltipOffset})
+	if p.X+size.X > root.X {
+		p.X = t.cursor.X - size.X
+	}
+	if p.Y+size.Y > root.Y {
+		p.Y = t.cursor.Y - size.Y - tooltipOffset/2
+	}
+	t.overlay = &event.Overlay{Target: t.tip, Position: p, Passive: true}
+	return t.dispatcher.Open(t.overlay)
+}
+
+func (t *Tooltip[T]) hide() error {
+	t.ticks = 0
+	if t.overlay == nil {
+		return nil
+	}
+	overlay := t.overlay
+	t.overlay = nil
+	return t.dispatcher.Close(overlay)
+}
+
+func (t *Tooltip[T]) HandleEvent(e *event.Event) error {
+	switch e.Type {
+	case event.PointerEnter:
+		t.hovered, t.pressed = true, false
+		t.cursor, t.dispatcher, t.ticks = e.Position, e.Dispatcher(), 0
+	case event.PointerLeave:
+		t.hovered = false
+		return t.hide()
+	case event.PointerMove:
+		// The delay starts over while the pointer moves, until the tip shows.
+		if t.hovered && e.Phase != event.Bubble && t.overlay == nil {
+			t.cursor, t.ticks = e.Position, 0
+		}
+	case event.PointerDown:
+		if e.Phase != event.Bubble {
+			t.pressed = true
+			return t.hide()
+		}
+	}
+	return nil
+}
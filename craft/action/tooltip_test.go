@@ -0,0 +1,122 @@
+package action
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ craft.Craft = NewTextTooltip(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), "tip", color.White)
+
+func TestTooltip(t *testing.T) {
+	tip := craft.NewFill(types.Size{X: 40, Y: 20}, color.White)
+	tooltip := NewTooltip(craft.NewFill(types.Size{X: 50, Y: 50}, color.White), tip).SetDelay(3)
+	root := craft.NewHorizontalStack(craft.NewFill(types.Size{X: 50, Y: 50}, color.White), tooltip)
+	in := input.New()
+	d := event.NewDispatcher()
+	tick := func(s input.State) {
+		in.Update(s)
+		d.Dispatch(root, in)
+		root.Update(types.Position{})
+	}
+	at := func(x, y int) input.State {
+		return input.State{Cursor: types.Position{X: x, Y: y}}
+	}
+	pressed := func(x, y int) input.State {
+		return input.State{Cursor: types.Position{X: x, Y: y}, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}}
+	}
+
+	tests := []struct {
+		input input.State
+		shown bool
+	}{
+		{at(60, 5), false},
+		{at(60, 5), false},
+		// moving starts the delay over
+		{at(61, 5), false},
+		{at(61, 5), false},
+		{at(61, 5), true},
+		{at(62, 5), true},
+		{at(5, 5), false},
+		{at(60, 5), false},
+		{pressed(60, 5), false},
+		{at(60, 5), false},
+		{at(60, 5), false},
+		{at(60, 5), false},
+		{at(60, 5), false},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			tick(tt.input)
+			if tooltip.Shown() != tt.shown {
+				t.Errorf("Shown should return %v, but got %v", tt.shown, tooltip.Shown())
+			}
+			if n := len(d.Overlays()); (n > 0) != tt.shown {
+				t.Errorf("the tip should be open %v, but got %d overlays", tt.shown, n)
+			}
+		})
+	}
+}
+
+func TestTooltipUnderDialog(t *testing.T) {
+	tooltip := NewTooltip(craft.NewFill(types.Size{X: 50, Y: 50}, color.White), craft.NewFill(types.Size{X: 10, Y: 10}, color.White)).SetDelay(1)
+	root := craft.NewHorizontalStack(tooltip, craft.NewFill(types.Size{X: 250, Y: 200}, color.White))
+	in := input.New()
+	d := event.NewDispatcher()
+	tick := func(x, y int) {
+		in.Update(input.State{Cursor: types.Position{X: x, Y: y}})
+		d.Dispatch(root, in)
+		root.Update(types.Position{})
+	}
+
+	tick(5, 5)
+	tick(5, 5)
+	if !tooltip.Shown() {
+		t.Fatalf("the tip should be shown")
+	}
+	dialog := craft.NewMessageBox("title", "message", color.White)
+	dialog.Show(d)
+	tick(6, 5)
+	tick(290, 190)
+	if !dialog.IsOpen() || tooltip.Shown() {
+		t.Errorf("hiding the tip should leave the dialog open, but got %v", dialog.IsOpen())
+	}
+}
+
+func TestTooltipPosition(t *testing.T) {
+	tests := []struct {
+		cursor types.Position
+		want   types.Position
+	}{
+		{types.Position{X: 55, Y: 5}, types.Position{X: 71, Y: 21}},
+		{types.Position{X: 95, Y: 5}, types.Position{X: 75, Y: 21}},
+		{types.Position{X: 55, Y: 45}, types.Position{X: 71, Y: 17}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			tip := craft.NewFill(types.Size{X: 20, Y: 20}, color.White)
+			tooltip := NewTooltip(craft.NewFill(types.Size{X: 50, Y: 50}, color.White), tip).SetDelay(1)
+			root := craft.NewHorizontalStack(craft.NewFill(types.Size{X: 50, Y: 50}, color.White), tooltip)
+			in := input.New()
+			d := event.NewDispatcher()
+			in.Update(input.State{Cursor: tt.cursor})
+			d.Dispatch(root, in)
+			root.Update(types.Position{})
+
+			if len(d.Overlays()) != 1 {
+				t.Fatalf("the tip should be open")
+			}
+			if got := d.Overlays()[0].Position; got != tt.want {
+				t.Errorf("the tip should be at %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
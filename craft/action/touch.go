@@ -0,0 +1,268 @@
+package action
+
+import (
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// The recognizers in this file follow touches only.
+// Like Draggable, they watch the events before the wrapped craft handles them
+// and do not stop them.
+
+const (
+	DefaultSwipeDistance = 40
+	DefaultSwipeDuration = 20
+)
+
+// finger is a touch started on a craft.
+type finger struct {
+	id       ebiten.TouchID
+	position types.Position
+}
+
+// fingers are the touches started on a craft, in the order they started.
+type fingers []finger
+
+// track updates the fingers with a touch event.
+// It returns the fingers before the event and whether it changed them.
+func (f *fingers) track(e *event.Event) (fingers, bool) {
+	if !e.Touch || e.Phase == event.Bubble {
+		return nil, false
+	}
+
+	prev := slices.Clone(*f)
+	i := slices.IndexFunc(*f, func(f finger) bool { return f.id == e.TouchID })
+	switch {
+	case e.Type == event.PointerDown && i < 0:
+		*f = append(*f, finger{e.TouchID, e.Position})
+	case e.Type == event.PointerMove && i >= 0:
+		(*f)[i].position = e.Position
+	case e.Type == event.PointerUp && i >= 0:
+		*f = slices.Delete(*f, i, i+1)
+	default:
+		return nil, false
+	}
+	return prev, true
+}
+
+// center is the centroid of the fingers.
+func (f fingers) center() types.Position {
+	if len(f) == 0 {
+		return types.Position{}
+	}
+	c := types.Position{}
+	for _, f := range f {
+		c = c.Add(f.position)
+	}
+	return types.Position{X: c.X / len(f), Y: c.Y / len(f)}
+}
+
+// span is the distance between the first two fingers.
+func (f fingers) span() float64 {
+	if len(f) < 2 {
+		return 0
+	}
+	d := f[1].position.Sub(f[0].position)
+	return math.Hypot(float64(d.X), float64(d.Y))
+}
+
+type PanHandler[T craft.Craft] func(c T, delta types.Position) error
+
+// Pan
+//
+// Pan calls the handler with how far the fingers on the wrapped craft moved,
+// measured at their center.
+type Pan[T craft.Craft] struct {
+	wrapper[T]
+	fingers fingers
+	handler PanHandler[T]
+}
+
+func NewPan[T craft.Craft](craft T, handler PanHandler[T]) *Pan[T] {
+	return &Pan[T]{wrapper[T]{craft}, nil, handler}
+}
+
+func (p *Pan[T]) AddText(str string, color color.Color) craft.Self {
+	p.craft.AddText(str, color)
+	return p
+}
+
+func (p *Pan[T]) SetText(str string, color color.Color) craft.Self {
+	p.craft.SetText(str, color)
+	return p
+}
+
+func (p *Pan[T]) ClearText() craft.Self {
+	p.craft.ClearText()
+	return p
+}
+
+func (p *Pan[T]) HandleEvent(e *event.Event) error {
+	prev, ok := p.fingers.track(e)
+	if !ok || e.Type != event.PointerMove {
+		return nil
+	}
+	delta := p.fingers.center().Sub(prev.center())
+	if delta == (types.Position{}) {
+		return nil
+	}
+	return p.handler(p.craft, delta)
+}
+
+type PinchHandler[T craft.Craft] func(c T, scale float64, center types.Position) error
+
+// Pinch
+//
+// Pinch calls the handler when two fingers on the wrapped craft move apart or together,
+// with the change of the distance between them since the last call as a scale factor,
+// and the point between them.
+type Pinch[T craft.Craft] struct {
+	wrapper[T]
+	fingers fingers
+	handler PinchHandler[T]
+}
+
+func NewPinch[T craft.Craft](craft T, handler PinchHandler[T]) *Pinch[T] {
+	return &Pinch[T]{wrapper[T]{craft}, nil, handler}
+}
+
+func (p *Pinch[T]) AddText(str string, color color.Color) craft.Self {
+	p.craft.AddText(str, color)
+	return p
+}
+
+func (p *Pinch[T]) SetText(str string, color color.Color) craft.Self {
+	p.craft.SetText(str, color)
+	return p
+}
+
+func (p *Pinch[T]) ClearText() craft.Self {
+	p.craft.ClearText()
+	return p
+}
+
+func (p *Pinch[T]) HandleEvent(e *event.Event) error {
+	prev, ok := p.fingers.track(e)
+	if !ok || e.Type != event.PointerMove || len(p.fingers) < 2 {
+		return nil
+	}
+	before, after := prev.span(), p.fingers.span()
+	if before == 0 || before == after {
+		return nil
+	}
+	return p.handler(p.craft, after/before, p.fingers[:2].center())
+}
+
+type SwipeDirection int
+
+const (
+	SwipeLeft SwipeDirection = iota
+	SwipeRight
+	SwipeUp
+	SwipeDown
+)
+
+type SwipeHandler[T craft.Craft] func(c T, direction SwipeDirection) error
+
+// Swipe
+//
+// Swipe calls the handler when a single finger is flicked across the wrapped craft:
+// lifted within the duration, counted in ticks, after moving at least the distance.
+type Swipe[T craft.Craft] struct {
+	wrapper[T]
+	fingers  fingers
+	handler  SwipeHandler[T]
+	distance int
+	duration int
+	start    types.Position
+	ticks    int
+	multi    bool
+}
+
+func NewSwipe[T craft.Craft](craft T, handler SwipeHandler[T]) *Swipe[T] {
+	return &Swipe[T]{wrapper[T]{craft}, nil, handler, DefaultSwipeDistance, DefaultSwipeDuration, types.Position{}, 0, false}
+}
+
+// SetDistance sets the minimum distance in pixels of a swipe.
+func (s *Swipe[T]) SetDistance(distance int) *Swipe[T] {
+	s.distance = distance
+	return s
+}
+
+// SetDuration sets the maximum number of ticks of a swipe.
+func (s *Swipe[T]) SetDuration(ticks int) *Swipe[T] {
+	s.duration = ticks
+	return s
+}
+
+func (s *Swipe[T]) AddText(str string, color color.Color) craft.Self {
+	s.craft.AddText(str, color)
+	return s
+}
+
+func (s *Swipe[T]) SetText(str string, color color.Color) craft.Self {
+	s.craft.SetText(str, color)
+	return s
+}
+
+func (s *Swipe[T]) ClearText() craft.Self {
+	s.craft.ClearText()
+	return s
+}
+
+func (s *Swipe[T]) Update(p types.Position) error {
+	if len(s.fingers) > 0 {
+		s.ticks++
+	}
+	return s.craft.Update(p)
+}
+
+func (s *Swipe[T]) HandleEvent(e *event.Event) error {
+	prev, ok := s.fingers.track(e)
+	if !ok {
+		return nil
+	}
+
+	switch e.Type {
+	case event.PointerDown:
+		if len(prev) == 0 {
+			s.start = e.Position
+			s.ticks = 0
+			s.multi = false
+		} else {
+			s.multi = true
+		}
+	case event.PointerUp:
+		if s.multi || len(s.fingers) > 0 || s.ticks > s.duration {
+			return nil
+		}
+		if d, ok := s.direction(e.Position.Sub(s.start)); ok {
+			return s.handler(s.craft, d)
+		}
+	}
+	return nil
+}
+
+// direction is the direction of the dominant axis of delta, if it is long enough.
+func (s *Swipe[T]) direction(delta types.Position) (SwipeDirection, bool) {
+	abs := func(n int) int { return max(n, -n) }
+	switch {
+	case abs(delta.X) >= abs(delta.Y) && abs(delta.X) >= s.distance:
+		if delta.X < 0 {
+			return SwipeLeft, true
+		}
+		return SwipeRight, true
+	case abs(delta.Y) > abs(delta.X) && abs(delta.Y) >= s.distance:
+		if delta.Y < 0 {
+			return SwipeUp, true
+		}
+		return SwipeDown, true
+	}
+	return 0, false
+}
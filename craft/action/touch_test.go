@@ -0,0 +1,164 @@
+package action
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ craft.Craft = NewPan(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), nil)
+var _ craft.Craft = NewPinch(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), nil)
+var _ craft.Craft = NewSwipe(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), nil)
+
+// fingersAt returns the touches with ids 0, 1, ... at the positions.
+func fingersAt(positions ...types.Position) []input.Touch {
+	ts := make([]input.Touch, len(positions))
+	for i, p := range positions {
+		ts[i] = input.Touch{ID: ebiten.TouchID(i), Position: p}
+	}
+	return ts
+}
+
+// playTouches runs the ticks of touches on c, like Game does.
+func playTouches(t *testing.T, c craft.Craft, ticks [][]input.Touch) {
+	t.Helper()
+	in := input.New()
+	d := event.NewDispatcher()
+	for _, ts := range ticks {
+		in.Update(input.State{Touches: ts})
+		if err := d.Dispatch(c, in); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Update(types.Position{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPan(t *testing.T) {
+	tests := []struct {
+		ticks [][]input.Touch
+		want  []types.Position
+	}{
+		{[][]input.Touch{
+			fingersAt(types.Position{X: 10, Y: 10}),
+			fingersAt(types.Position{X: 15, Y: 10}),
+			fingersAt(types.Position{X: 15, Y: 20}),
+			{},
+		}, []types.Position{{X: 5, Y: 0}, {X: 0, Y: 10}}},
+		{[][]input.Touch{
+			fingersAt(types.Position{X: 10, Y: 10}, types.Position{X: 30, Y: 10}),
+			fingersAt(types.Position{X: 20, Y: 10}, types.Position{X: 30, Y: 10}),
+		}, []types.Position{{X: 5, Y: 0}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			got := []types.Position{}
+			playTouches(t, NewPan(craft.NewFill(types.Size{X: 100, Y: 100}, color.White),
+				func(_ *craft.Fill, delta types.Position) error { got = append(got, delta); return nil }), tt.ticks)
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("handler should be called with %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPinch(t *testing.T) {
+	tests := []struct {
+		ticks [][]input.Touch
+		want  []string
+	}{
+		{[][]input.Touch{
+			fingersAt(types.Position{X: 40, Y: 50}, types.Position{X: 60, Y: 50}),
+			fingersAt(types.Position{X: 30, Y: 50}, types.Position{X: 60, Y: 50}),
+			fingersAt(types.Position{X: 30, Y: 50}, types.Position{X: 90, Y: 50}),
+			fingersAt(types.Position{X: 30, Y: 50}, types.Position{X: 60, Y: 50}),
+		}, []string{"1.50 {45 50}", "2.00 {60 50}", "0.50 {45 50}"}},
+		{[][]input.Touch{
+			fingersAt(types.Position{X: 40, Y: 50}),
+			fingersAt(types.Position{X: 60, Y: 50}),
+		}, []string{}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			got := []string{}
+			playTouches(t, NewPinch(craft.NewFill(types.Size{X: 100, Y: 100}, color.White),
+				func(_ *craft.Fill, scale float64, center types.Position) error {
+					got = append(got, fmt.Sprintf("%.2f %v", scale, center))
+					return nil
+				}), tt.ticks)
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("handler should be called with %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSwipe(t *testing.T) {
+	swipe := func(from, to types.Position, ticks int) [][]input.Touch {
+		result := [][]input.Touch{fingersAt(from)}
+		for i := 1; i < ticks; i++ {
+			result = append(result, fingersAt(types.Position{
+				X: from.X + (to.X-from.X)*i/(ticks-1),
+				Y: from.Y + (to.Y-from.Y)*i/(ticks-1),
+			}))
+		}
+		return append(result, []input.Touch{})
+	}
+
+	tests := []struct {
+		ticks [][]input.Touch
+		want  []SwipeDirection
+	}{
+		{swipe(types.Position{X: 80, Y: 50}, types.Position{X: 20, Y: 55}, 5), []SwipeDirection{SwipeLeft}},
+		{swipe(types.Position{X: 20, Y: 50}, types.Position{X: 80, Y: 45}, 5), []SwipeDirection{SwipeRight}},
+		{swipe(types.Position{X: 50, Y: 80}, types.Position{X: 50, Y: 20}, 5), []SwipeDirection{SwipeUp}},
+		{swipe(types.Position{X: 50, Y: 20}, types.Position{X: 50, Y: 80}, 5), []SwipeDirection{SwipeDown}},
+		// too short
+		{swipe(types.Position{X: 50, Y: 50}, types.Position{X: 70, Y: 50}, 5), []SwipeDirection{}},
+		// too slow
+		{swipe(types.Position{X: 20, Y: 50}, types.Position{X: 80, Y: 50}, 30), []SwipeDirection{}},
+		// two fingers
+		{[][]input.Touch{
+			fingersAt(types.Position{X: 20, Y: 50}),
+			fingersAt(types.Position{X: 50, Y: 50}, types.Position{X: 10, Y: 10}),
+			fingersAt(types.Position{X: 80, Y: 50}),
+			{},
+		}, []SwipeDirection{}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			got := []SwipeDirection{}
+			playTouches(t, NewSwipe(craft.NewFill(types.Size{X: 100, Y: 100}, color.White),
+				func(_ *craft.Fill, d SwipeDirection) error { got = append(got, d); return nil }), tt.ticks)
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("handler should be called with %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClickTouch(t *testing.T) {
+	count := 0
+	playTouches(t, NewClick(craft.NewFill(types.Size{X: 100, Y: 100}, color.White), ebiten.MouseButtonLeft,
+		func(*craft.Fill) error { count++; return nil }), [][]input.Touch{
+		fingersAt(types.Position{X: 50, Y: 50}),
+		{},
+	})
+
+	if count != 1 {
+		t.Errorf("handler should be called 1 time, but got %d", count)
+	}
+}
@@ -0,0 +1,49 @@
+package action
+
+import (
+	"image/color"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+)
+
+type WheelHandler[T craft.Craft] func(c T, x, y float64) error
+
+// Wheel
+//
+// Wheel calls the handler with the scroll deltas when the wheel turns over the wrapped craft.
+// The event stops there, so a scrolling craft inside another one does not scroll both.
+type Wheel[T craft.Craft] struct {
+	wrapper[T]
+	handler WheelHandler[T]
+}
+
+func NewWheel[T craft.Craft](craft T, handler WheelHandler[T]) *Wheel[T] {
+	return &Wheel[T]{wrapper[T]{craft}, handler}
+}
+
+func (w *Wheel[T]) AddText(str string, color color.Color) craft.Self {
+	w.craft.AddText(str, color)
+	return w
+}
+
+func (w *Wheel[T]) SetText(str string, color color.Color) craft.Self {
+	w.craft.SetText(str, color)
+	return w
+}
+
+func (w *Wheel[T]) ClearText() craft.Self {
+	w.craft.ClearText()
+	return w
+}
+
+func (w *Wheel[T]) HandleEvent(e *event.Event) error {
+	if e.Phase == event.Capture || e.Type != event.Wheel {
+		return nil
+	}
+	e.StopPropagation()
+	if w.handler == nil {
+		return nil
+	}
+	return w.handler(w.craft, e.WheelX, e.WheelY)
+}
@@ -0,0 +1,43 @@
+package action
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+)
+
+func TestWheel(t *testing.T) {
+	tests := []struct {
+		cursor types.Position
+		wheelY float64
+		want   []float64
+	}{
+		{types.Position{X: 5, Y: 5}, -1, []float64{-1}},
+		{types.Position{X: 5, Y: 5}, 0, nil},
+		{types.Position{X: 15, Y: 5}, 2, nil},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			var got []float64
+			w := NewWheel(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), func(f *craft.Fill, x, y float64) error {
+				got = append(got, y)
+				return nil
+			})
+			root := craft.NewHorizontalStack(w, craft.NewFill(types.Size{X: 10, Y: 10}, color.White))
+
+			in := input.New()
+			in.Update(input.State{Cursor: tt.cursor, WheelY: tt.wheelY})
+			event.NewDispatcher().Dispatch(root, in)
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("handler should be called with %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
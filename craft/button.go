@@ -0,0 +1,217 @@
+package craft
+
+import (
+	"image/color"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type ButtonHandler func(*Button) error
+
+// ButtonState is how a button is drawn.
+type ButtonState int
+
+const (
+	ButtonNormal ButtonState = iota
+	ButtonHover
+	ButtonPressed
+	ButtonDisabled
+)
+
+// Button Craft
+//
+// Button draws the craft of its state with the label centered on top.
+// It is clicked when the left button or a touch is pressed and released on it,
+// or when it is focused and activated with the keyboard or a gamepad.
+// A disabled button is never clicked nor focused.
+// States without a craft are drawn with the normal one.
+//
+// ```
+// +---------+
+// |  label  |
+// +---------+
+// ```
+type Button struct {
+	crafts     [ButtonDisabled + 1]Craft
+	label      string
+	labelColor color.Color
+	disabled   bool
+	hovered    bool
+	pressed    bool
+	onClick    ButtonHandler
+	texts      []types.TextInfo
+	direction  types.Direction
+}
+
+func NewButton(normal Craft, onClick ButtonHandler) *Button {
+	b := &Button{onClick: onClick, texts: []types.TextInfo{}}
+	b.crafts[ButtonNormal] = normal
+	return b
+}
+
+// SetCraft sets the craft drawn in state.
+// The crafts of every state should have the same size.
+func (b *Button) SetCraft(state ButtonState, c Craft) *Button {
+	b.crafts[state] = c
+	SetDirection(c, b.direction)
+	return b
+}
+
+func (b *Button) SetLabel(str string, color color.Color) *Button {
+	b.label, b.labelColor = str, color
+	return b
+}
+
+func (b *Button) Label() string {
+	return b.label
+}
+
+// SetDisabled disables the button, releasing it if it is pressed.
+func (b *Button) SetDisabled(disabled bool) *Button {
+	b.disabled = disabled
+	b.pressed = false
+	return b
+}
+
+func (b *Button) Disabled() bool {
+	return b.disabled
+}
+
+func (b *Button) OnClick(handler ButtonHandler) *Button {
+	b.onClick = handler
+	return b
+}
+
+// Click calls the click handler unless the button is disabled.
+func (b *Button) Click() error {
+	if b.disabled || b.onClick == nil {
+		return nil
+	}
+	return b.onClick(b)
+}
+
+func (b *Button) State() ButtonState {
+	switch {
+	case b.disabled:
+		return ButtonDisabled
+	case b.pressed && b.hovered:
+		return ButtonPressed
+	case b.hovered:
+		return ButtonHover
+	}
+	return ButtonNormal
+}
+
+// current is the craft drawn in the current state.
+func (b *Button) current() Craft {
+	if c := b.crafts[b.State()]; c != nil {
+		return c
+	}
+	return b.crafts[ButtonNormal]
+}
+
+func (b *Button) Image() *ebiten.Image {
+	if b.label == "" && len(b.texts) == 0 {
+		return b.current().Image()
+	}
+
+	size := b.Size()
+	image := ebiten.NewImage(size.X, size.Y)
+	image.DrawImage(b.current().Image(), nil)
+	if b.label != "" {
+		util.DrawTextAt(image, b.label, b.labelColor, types.Position{
+			X: (size.X - util.TextWidth(b.label)) / 2,
+			Y: (size.Y - util.LineHeight()) / 2,
+		})
+	}
+	util.DrawTexts(image, b.texts, b.direction)
+
+	return image
+}
+
+func (b *Button) Size() types.Size {
+	return b.current().Size()
+}
+
+func (b *Button) AddText(str string, color color.Color) Self {
+	b.texts = append(b.texts, types.TextInfo{Str: str, Color: color})
+	return b
+}
+
+func (b *Button) SetText(str string, color color.Color) Self {
+	b.texts = []types.TextInfo{{Str: str, Color: color}}
+	return b
+}
+
+func (b *Button) ClearText() Self {
+	b.texts = []types.TextInfo{}
+	return b
+}
+
+func (b *Button) SetDirection(d types.Direction) {
+	b.direction = d
+	for _, c := range b.crafts {
+		if c != nil {
+			SetDirection(c, d)
+		}
+	}
+}
+
+func (b *Button) Const() *Image {
+	return &Image{b.Image(), []types.TextInfo{}, b.direction}
+}
+
+func (b *Button) Update(p types.Position) error {
+	return b.current().Update(p)
+}
+
+func (b *Button) Focusable() bool {
+	return !b.disabled
+}
+
+func (b *Button) CursorShape() ebiten.CursorShapeType {
+	if b.disabled {
+		return ebiten.CursorShapeNotAllowed
+	}
+	return ebiten.CursorShapePointer
+}
+
+func (b *Button) HandleEvent(e *event.Event) error {
+	switch e.Type {
+	case event.PointerEnter:
+		b.hovered = true
+	case event.PointerLeave:
+		b.hovered = false
+	case event.PointerDown:
+		if b.disabled || e.Button != ebiten.MouseButtonLeft {
+			return nil
+		}
+		b.pressed, b.hovered = true, true
+		e.StopPropagation()
+	case event.PointerMove:
+		if b.pressed {
+			b.hovered = e.Inside()
+		}
+	case event.PointerUp:
+		if !b.pressed || e.Button != ebiten.MouseButtonLeft {
+			return nil
+		}
+		b.pressed = false
+		b.hovered = e.Inside() && !e.Touch
+		if !e.Inside() {
+			return nil
+		}
+		e.StopPropagation()
+		return b.Click()
+	case event.Activate:
+		if b.disabled {
+			return nil
+		}
+		e.StopPropagation()
+		return b.Click()
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package craft
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ Craft = NewButton(NewFill(types.Size{X: 10, Y: 10}, color.White), nil)
+
+func TestButtonState(t *testing.T) {
+	normal := NewFill(types.Size{X: 10, Y: 10}, color.White)
+	hover := NewFill(types.Size{X: 10, Y: 10}, color.Gray{0x80})
+	b := NewButton(normal, nil).SetCraft(ButtonHover, hover)
+	root := NewHorizontalStack(NewFill(types.Size{X: 10, Y: 10}, color.White), b)
+
+	left := []ebiten.MouseButton{ebiten.MouseButtonLeft}
+	tests := []struct {
+		state input.State
+		want  ButtonState
+		craft Craft
+	}{
+		{input.State{Cursor: types.Position{X: 5, Y: 5}}, ButtonNormal, normal},
+		{input.State{Cursor: types.Position{X: 15, Y: 5}}, ButtonHover, hover},
+		{input.State{Cursor: types.Position{X: 15, Y: 5}, Buttons: left}, ButtonPressed, normal},
+		// dragged out while pressed
+		{input.State{Cursor: types.Position{X: 5, Y: 5}, Buttons: left}, ButtonNormal, normal},
+		{input.State{Cursor: types.Position{X: 15, Y: 5}, Buttons: left}, ButtonPressed, normal},
+		{input.State{Cursor: types.Position{X: 15, Y: 5}}, ButtonHover, hover},
+	}
+
+	in := input.New()
+	d := event.NewDispatcher()
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			in.Update(tt.state)
+			d.Dispatch(root, in)
+			if got := b.State(); got != tt.want {
+				t.Errorf("State should return %v, but got %v", tt.want, got)
+			}
+			if got := b.current(); got != tt.craft {
+				t.Errorf("current should return %v, but got %v", tt.craft, got)
+			}
+		})
+	}
+
+	b.SetDisabled(true)
+	if got := b.State(); got != ButtonDisabled {
+		t.Errorf("State should return %v, but got %v", ButtonDisabled, got)
+	}
+}
+
+func TestButtonClick(t *testing.T) {
+	at := func(x int, buttons ...ebiten.MouseButton) input.State {
+		return input.State{Cursor: types.Position{X: x, Y: 5}, Buttons: buttons}
+	}
+	left, right := ebiten.MouseButtonLeft, ebiten.MouseButtonRight
+	tests := []struct {
+		disabled bool
+		states   []input.State
+		want     int
+	}{
+		{false, []input.State{at(15, left), at(15)}, 1},
+		{false, []input.State{at(15, right), at(15)}, 0},
+		// released outside
+		{false, []input.State{at(15, left), at(5, left), at(5)}, 0},
+		// pressed outside
+		{false, []input.State{at(5, left), at(15, left), at(15)}, 0},
+		{true, []input.State{at(15, left), at(15)}, 0},
+		{false, []input.State{{Keys: []ebiten.Key{ebiten.KeyTab}}, {}, {Keys: []ebiten.Key{ebiten.KeyEnter}}, {}}, 1},
+		{true, []input.State{{Keys: []ebiten.Key{ebiten.KeyTab}}, {}, {Keys: []ebiten.Key{ebiten.KeyEnter}}, {}}, 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			count := 0
+			b := NewButton(NewFill(types.Size{X: 10, Y: 10}, color.White), func(*Button) error { count++; return nil }).
+				SetLabel("OK", color.Black).
+				SetDisabled(tt.disabled)
+			root := NewHorizontalStack(NewFill(types.Size{X: 10, Y: 10}, color.White), b)
+
+			in := input.New()
+			d := event.NewDispatcher()
+			for _, s := range tt.states {
+				in.Update(s)
+				d.Dispatch(root, in)
+			}
+
+			if count != tt.want {
+				t.Errorf("handler should be called %d times, but got %d", tt.want, count)
+			}
+			if tt.disabled && d.Focused() == b {
+				t.Errorf("Focused should not return a disabled button")
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package craft
+
+import (
+	"image/color"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var (
+	controlBackground = color.RGBA{0x20, 0x20, 0x20, 0xff}
+	controlBorder     = color.RGBA{0x80, 0x80, 0x80, 0xff}
+	controlHover      = color.RGBA{0x40, 0x40, 0x40, 0xff}
+	controlAccent     = color.RGBA{0x40, 0x80, 0xff, 0xff}
+	controlDisabled   = color.RGBA{0x00, 0x00, 0x00, 0x80}
+)
+
+// labelGap is the space between a control and its label.
+const labelGap = 4
+
+// check is what Checkbox and Toggle share: a Switch of the unchecked and checked crafts,
+// with a label beside it, flipped when clicked or activated.
+type check struct {
+	crafts     *Switch
+	checked    bool
+	label      string
+	labelColor color.Color
+	disabled   bool
+	pressed    bool
+	texts      []types.TextInfo
+	direction  types.Direction
+}
+
+func newCheck(unchecked, checked Craft, label string, color color.Color) check {
+	return check{
+		crafts:     NewSwitch(unchecked, checked),
+		label:      label,
+		labelColor: color,
+		texts:      []types.TextInfo{},
+	}
+}
+
+func (c *check) set(checked bool) {
+	c.checked = checked
+	c.crafts.index = 0
+	if checked {
+		c.crafts.index = 1
+	}
+}
+
+func (c *check) Checked() bool {
+	return c.checked
+}
+
+func (c *check) Label() string {
+	return c.label
+}
+
+func (c *check) Disabled() bool {
+	return c.disabled
+}
+
+// labelled draws control with the label beside it: on the right, or on the left in right-to-left text.
+func labelled(control *ebiten.Image, label string, clr color.Color, disabled bool, texts []types.TextInfo, d types.Direction) *ebiten.Image {
+	size := labelledSize(types.Size(control.Bounds().Size()), label)
+	image := ebiten.NewImage(size.X, size.Y)
+
+	cs := control.Bounds().Size()
+	op := &ebiten.DrawImageOptions{}
+	labelAt := types.Position{X: cs.X + labelGap, Y: (size.Y - util.LineHeight()) / 2}
+	if d == types.RightToLeft {
+		op.GeoM.Translate(float64(size.X-cs.X), 0)
+		labelAt.X = 0
+	}
+	op.GeoM.Translate(0, float64(size.Y-cs.Y)/2)
+	image.DrawImage(control, op)
+	if label != "" {
+		util.DrawTextAt(image, label, clr, labelAt)
+	}
+	if disabled {
+		vector.DrawFilledRect(image, 0, 0, float32(size.X), float32(size.Y), controlDisabled, false)
+	}
+	util.DrawTexts(image, texts, d)
+
+	return image
+}
+
+func labelledSize(control types.Size, label string) types.Size {
+	if label == "" {
+		return control
+	}
+	return types.Size{
+		X: control.X + labelGap + util.TextWidth(label),
+		Y: max(control.Y, util.LineHeight()),
+	}
+}
+
+func (c *check) Image() *ebiten.Image {
+	return labelled(c.crafts.Image(), c.label, c.labelColor, c.disabled, c.texts, c.direction)
+}
+
+func (c *check) Size() types.Size {
+	return labelledSize(c.crafts.Size(), c.label)
+}
+
+func (c *check) SetDirection(d types.Direction) {
+	c.direction = d
+	c.crafts.SetDirection(d)
+}
+
+func (c *check) Const() *Image {
+	return &Image{c.Image(), []types.TextInfo{}, c.direction}
+}
+
+func (c *check) Update(p types.Position) error {
+	return c.crafts.Update(p)
+}
+
+func (c *check) Focusable() bool {
+	return !c.disabled
+}
+
+func (c *check) CursorShape() ebiten.CursorShapeType {
+	if c.disabled {
+		return ebiten.CursorShapeNotAllowed
+	}
+	return ebiten.CursorShapePointer
+}
+
+// clicked reports whether e flips the check:
+// the left button or a touch pressed and released on it, or an activation.
+func (c *check) clicked(e *event.Event) bool {
+	if c.disabled {
+		return false
+	}
+	switch e.Type {
+	case event.PointerDown:
+		if e.Button == ebiten.MouseButtonLeft {
+			c.pressed = true
+			e.StopPropagation()
+		}
+	case event.PointerUp:
+		if !c.pressed || e.Button != ebiten.MouseButtonLeft {
+			return false
+		}
+		c.pressed = false
+		if e.Inside() {
+			e.StopPropagation()
+			return true
+		}
+	case event.Activate:
+		e.StopPropagation()
+		return true
+	}
+	return false
+}
+
+func checkboxImage(checked bool) Craft {
+	s := util.LineHeight()
+	image := ebiten.NewImage(s, s)
+	image.Fill(controlBackground)
+	vector.StrokeRect(image, 0.5, 0.5, float32(s)-1, float32(s)-1, 1, controlBorder, false)
+	if checked {
+		vector.DrawFilledRect(image, 3, 3, float32(s)-6, float32(s)-6, controlAccent, false)
+	}
+	return &Image{image, []types.TextInfo{}, types.LeftToRight}
+}
+
+type CheckboxHandler func(*Checkbox) error
+
+// Checkbox Craft
+//
+// Checkbox is a box checked and unchecked by clicking it, or by activating it while focused.
+//
+// ```
+// [x] label
+// ```
+type Checkbox struct {
+	check
+	onChange CheckboxHandler
+}
+
+func NewCheckbox(label string, color color.Color) *Checkbox {
+	return &Checkbox{check: newCheck(checkboxImage(false), checkboxImage(true), label, color)}
+}
+
+// SetCrafts replaces the default boxes.
+func (c *Checkbox) SetCrafts(unchecked, checked Craft) *Checkbox {
+	c.crafts = NewSwitch(unchecked, checked)
+	c.crafts.SetDirection(c.direction)
+	c.set(c.checked)
+	return c
+}
+
+// SetChecked checks or unchecks the box without calling the change handler.
+func (c *Checkbox) SetChecked(checked bool) *Checkbox {
+	c.set(checked)
+	return c
+}
+
+func (c *Checkbox) SetDisabled(disabled bool) *Checkbox {
+	c.disabled = disabled
+	c.pressed = false
+	return c
+}
+
+func (c *Checkbox) OnChange(handler CheckboxHandler) *Checkbox {
+	c.onChange = handler
+	return c
+}
+
+func (c *Checkbox) AddText(str string, color color.Color) Self {
+	c.texts = append(c.texts, types.TextInfo{Str: str, Color: color})
+	return c
+}
+
+func (c *Checkbox) SetText(str string, color color.Color) Self {
+	c.texts = []types.TextInfo{{Str: str, Color: color}}
+	return c
+}
+
+func (c *Checkbox) ClearText() Self {
+	c.texts = []types.TextInfo{}
+	return c
+}
+
+func (c *Checkbox) HandleEvent(e *event.Event) error {
+	if !c.clicked(e) {
+		return nil
+	}
+	c.set(!c.checked)
+	if c.onChange != nil {
+		return c.onChange(c)
+	}
+	return nil
+}
+
+func toggleImage(on bool) Craft {
+	h := util.LineHeight()
+	w := h * 2
+	image := ebiten.NewImage(w, h)
+	track, x := controlBackground, float32(h)/2
+	if on {
+		track, x = controlAccent, float32(w)-float32(h)/2
+	}
+	vector.DrawFilledRect(image, float32(h)/2, 0, float32(w-h), float32(h), track, true)
+	vector.DrawFilledCircle(image, float32(h)/2, float32(h)/2, float32(h)/2, track, true)
+	vector.DrawFilledCircle(image, float32(w)-float32(h)/2, float32(h)/2, float32(h)/2, track, true)
+	vector.DrawFilledCircle(image, x, float32(h)/2, float32(h)/2-2, color.White, true)
+	return &Image{image, []types.TextInfo{}, types.LeftToRight}
+}
+
+type ToggleHandler func(*Toggle) error
+
+// Toggle Craft
+//
+// Toggle is a switch turned on and off like a Checkbox,
+// for settings that take effect at once.
+//
+// ```
+// (o ) label
+// ```
+type Toggle struct {
+	check
+	onChange ToggleHandler
+}
+
+func NewToggle(label string, color color.Color) *Toggle {
+	return &Toggle{check: newCheck(toggleImage(false), toggleImage(true), label, color)}
+}
+
+// SetCrafts replaces the default switches.
+func (t *Toggle) SetCrafts(off, on Craft) *Toggle {
+	t.crafts = NewSwitch(off, on)
+	t.crafts.SetDirection(t.direction)
+	t.set(t.checked)
+	return t
+}
+
+// SetOn turns the toggle on or off without calling the change handler.
+func (t *Toggle) SetOn(on bool) *Toggle {
+	t.set(on)
+	return t
+}
+
+func (t *Toggle) On() bool {
+	return t.checked
+}
+
+func (t *Toggle) SetDisabled(disabled bool) *Toggle {
+	t.disabled = disabled
+	t.pressed = false
+	return t
+}
+
+func (t *Toggle) OnChange(handler ToggleHandler) *Toggle {
+	t.onChange = handler
+	return t
+}
+
+func (t *Toggle) AddText(str string, color color.Color) Self {
+	t.texts = append(t.texts, types.TextInfo{Str: str, Color: color})
+	return t
+}
+
+func (t *Toggle) SetText(str string, color color.Color) Self {
+	t.texts = []types.TextInfo{{Str: str, Color: color}}
+	return t
+}
+
+func (t *Toggle) ClearText() Self {
+	t.texts = []types.TextInfo{}
+	return t
+}
+
+func (t *Toggle) HandleEvent(e *event.Event) error {
+	if !t.clicked(e) {
+		return nil
+	}
+	t.set(!t.checked)
+	if t.onChange != nil {
+		return t.onChange(t)
+	}
+	return nil
+}
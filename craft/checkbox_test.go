@@ -0,0 +1,87 @@
+package craft
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var (
+	_ Craft = NewCheckbox("check", color.White)
+	_ Craft = NewToggle("toggle", color.White)
+)
+
+// click dispatches a left click at p.
+func click(d *event.Dispatcher, root event.Target, in *input.Input, p types.Position) {
+	in.Update(input.State{Cursor: p, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}})
+	d.Dispatch(root, in)
+	in.Update(input.State{Cursor: p})
+	d.Dispatch(root, in)
+}
+
+func TestCheckbox(t *testing.T) {
+	changes := []bool{}
+	c := NewCheckbox("check", color.White).OnChange(func(c *Checkbox) error {
+		changes = append(changes, c.Checked())
+		return nil
+	})
+	in := input.New()
+	d := event.NewDispatcher()
+
+	click(d, c, in, types.Position{X: 30, Y: 5})
+	click(d, c, in, types.Position{X: 5, Y: 5})
+	c.SetChecked(true)
+	d.SetFocus(c)
+	d.Activate()
+	c.SetDisabled(true)
+	click(d, c, in, types.Position{X: 5, Y: 5})
+
+	if fmt.Sprint(changes) != "[true false false]" {
+		t.Errorf("handler should be called with [true false false], but got %v", changes)
+	}
+	if c.Checked() {
+		t.Errorf("Checked should return false, but got true")
+	}
+	if c.Focusable() {
+		t.Errorf("Focusable should return false when disabled, but got true")
+	}
+}
+
+func TestCheckboxSize(t *testing.T) {
+	tests := []struct {
+		label string
+		want  types.Size
+	}{
+		{"", types.Size{X: 16, Y: 16}},
+		{"ab", types.Size{X: 16 + labelGap + 12, Y: 16}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			if got := NewCheckbox(tt.label, color.White).Size(); got != tt.want {
+				t.Errorf("Size should return %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestToggle(t *testing.T) {
+	count := 0
+	off, on := NewFill(types.Size{X: 20, Y: 10}, color.Black), NewFill(types.Size{X: 20, Y: 10}, color.White)
+	toggle := NewToggle("", color.White).SetCrafts(off, on).SetOn(true).OnChange(func(*Toggle) error { count++; return nil })
+	if toggle.crafts.crafts[toggle.crafts.index] != on {
+		t.Errorf("SetCrafts should keep the toggle on")
+	}
+
+	in := input.New()
+	d := event.NewDispatcher()
+	click(d, toggle, in, types.Position{X: 5, Y: 5})
+	if toggle.On() || count != 1 {
+		t.Errorf("a click should turn the toggle off once, but got %v after %d calls", toggle.On(), count)
+	}
+}
@@ -5,34 +5,81 @@ import (
 	"image"
 	"image/color"
 
+	"github.com/a-skua/etk/craft/event"
 	"github.com/a-skua/etk/craft/internal/util"
 	"github.com/a-skua/etk/craft/types"
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
 // Craft
+//
+// Text added by AddText is an overlay owned by the craft:
+// it is drawn on top of the craft's image every time Image is called,
+// it is never forwarded to child crafts,
+// and it never mutates a source image.
+// SetText replaces every overlay text and ClearText removes them.
 type Craft interface {
 	Image() *ebiten.Image
 	Size() types.Size
 	AddText(string, color.Color) Self
+	SetText(string, color.Color) Self
+	ClearText() Self
 	Const() *Image
 	Update(types.Position) error
 }
 
 type Self = Craft
 
+// Directional is implemented by crafts that lay out text or children by direction.
+// Containers pass the direction on to their children.
+type Directional interface {
+	SetDirection(types.Direction)
+}
+
+// SetDirection marks the craft tree c as left-to-right or right-to-left, and returns c.
+//
+// In a right-to-left tree, text is aligned to the right and reordered by the
+// Unicode bidirectional algorithm, and HorizontalStack lays out its children
+// from right to left.
+func SetDirection[T Craft](c T, d types.Direction) T {
+	if c, ok := any(c).(Directional); ok {
+		c.SetDirection(d)
+	}
+	return c
+}
+
+// SetFace sets the font face of every text.
+//
+// Right-to-left text such as Arabic is shaped only by a *text.GoTextFace;
+// the default bitmap face just reorders it.
+func SetFace(face text.Face) {
+	util.Face = face
+}
+
 // Image Craft
 type Image struct {
-	image *ebiten.Image
+	image     *ebiten.Image
+	texts     []types.TextInfo
+	direction types.Direction
 }
 
 func NewImage(img image.Image) *Image {
 	image := ebiten.NewImageFromImage(img)
-	return &Image{image}
+	return &Image{image, []types.TextInfo{}, types.LeftToRight}
 }
 
 func (i *Image) Image() *ebiten.Image {
-	return i.image
+	if len(i.texts) == 0 {
+		return i.image
+	}
+
+	size := i.Size()
+	image := ebiten.NewImage(size.X, size.Y)
+	image.DrawImage(i.image, nil)
+	util.DrawTexts(image, i.texts, i.direction)
+
+	return image
 }
 
 func (i *Image) Size() types.Size {
@@ -40,36 +87,52 @@ func (i *Image) Size() types.Size {
 }
 
 func (i *Image) AddText(str string, color color.Color) Self {
-	util.DrawText(i.image, str, color)
+	i.texts = append(i.texts, types.TextInfo{Str: str, Color: color})
 	return i
 }
 
-func (i *Image) Const() *Image {
+func (i *Image) SetText(str string, color color.Color) Self {
+	i.texts = []types.TextInfo{{Str: str, Color: color}}
+	return i
+}
+
+func (i *Image) ClearText() Self {
+	i.texts = []types.TextInfo{}
 	return i
 }
 
+func (i *Image) SetDirection(d types.Direction) {
+	i.direction = d
+}
+
+func (i *Image) Const() *Image {
+	if len(i.texts) == 0 {
+		return i
+	}
+	return &Image{i.Image(), []types.TextInfo{}, i.direction}
+}
+
 func (i *Image) Update(p types.Position) error {
 	return nil
 }
 
 // Fill Craft
 type Fill struct {
-	size  types.Size
-	color color.Color
-	texts []types.TextInfo
+	size      types.Size
+	color     color.Color
+	texts     []types.TextInfo
+	direction types.Direction
 }
 
 func NewFill(size types.Size, color color.Color) *Fill {
-	return &Fill{size, color, []types.TextInfo{}}
+	return &Fill{size, color, []types.TextInfo{}, types.LeftToRight}
 }
 
 func (f *Fill) Image() *ebiten.Image {
 	image := ebiten.NewImage(f.size.X, f.size.Y)
 	image.Fill(f.color)
 
-	for _, t := range f.texts {
-		util.DrawText(image, t.Str, t.Color)
-	}
+	util.DrawTexts(image, f.texts, f.direction)
 
 	return image
 }
@@ -83,8 +146,22 @@ func (f *Fill) AddText(str string, color color.Color) Self {
 	return f
 }
 
+func (f *Fill) SetText(str string, color color.Color) Self {
+	f.texts = []types.TextInfo{{Str: str, Color: color}}
+	return f
+}
+
+func (f *Fill) ClearText() Self {
+	f.texts = []types.TextInfo{}
+	return f
+}
+
+func (f *Fill) SetDirection(d types.Direction) {
+	f.direction = d
+}
+
 func (f *Fill) Const() *Image {
-	return &Image{f.Image()}
+	return &Image{f.Image(), []types.TextInfo{}, f.direction}
 }
 
 func (f *Fill) Update(p types.Position) error {
@@ -93,17 +170,27 @@ func (f *Fill) Update(p types.Position) error {
 
 // Switch Craft
 type Switch struct {
-	crafts []Craft
-	index  int
-	texts  []types.TextInfo
+	crafts    []Craft
+	index     int
+	texts     []types.TextInfo
+	direction types.Direction
 }
 
 func NewSwitch(crafts ...Craft) *Switch {
-	return &Switch{crafts, 0, []types.TextInfo{}}
+	return &Switch{crafts, 0, []types.TextInfo{}, types.LeftToRight}
 }
 
 func (s *Switch) Image() *ebiten.Image {
-	return s.crafts[s.index].Image()
+	if len(s.texts) == 0 {
+		return s.crafts[s.index].Image()
+	}
+
+	size := s.Size()
+	image := ebiten.NewImage(size.X, size.Y)
+	image.DrawImage(s.crafts[s.index].Image(), nil)
+	util.DrawTexts(image, s.texts, s.direction)
+
+	return image
 }
 
 func (s *Switch) Size() types.Size {
@@ -115,12 +202,33 @@ func (s *Switch) AddText(str string, color color.Color) Self {
 	return s
 }
 
+func (s *Switch) SetText(str string, color color.Color) Self {
+	s.texts = []types.TextInfo{{Str: str, Color: color}}
+	return s
+}
+
+func (s *Switch) ClearText() Self {
+	s.texts = []types.TextInfo{}
+	return s
+}
+
+func (s *Switch) SetDirection(d types.Direction) {
+	s.direction = d
+	for _, c := range s.crafts {
+		SetDirection(c, d)
+	}
+}
+
 func (s *Switch) Update(p types.Position) error {
 	return s.crafts[s.index].Update(p)
 }
 
+func (s *Switch) Children() []event.Child {
+	return []event.Child{{Target: s.crafts[s.index]}}
+}
+
 func (s *Switch) Const() *Image {
-	return &Image{s.Image()}
+	return &Image{s.Image(), []types.TextInfo{}, s.direction}
 }
 
 func (s *Switch) Next() {
@@ -139,13 +247,14 @@ func (s *Switch) Prev() {
 
 // Box Craft
 type Box struct {
-	craft  Craft
-	margin types.Margin
-	texts  []types.TextInfo
+	craft     Craft
+	margin    types.Margin
+	texts     []types.TextInfo
+	direction types.Direction
 }
 
 func NewBox(c Craft, m types.Margin) *Box {
-	return &Box{c, m, []types.TextInfo{}}
+	return &Box{c, m, []types.TextInfo{}, types.LeftToRight}
 }
 
 func (b *Box) Image() *ebiten.Image {
@@ -156,9 +265,7 @@ func (b *Box) Image() *ebiten.Image {
 	op.GeoM.Translate(float64(b.margin.Left), float64(b.margin.Top))
 	image.DrawImage(b.craft.Image(), op)
 
-	for _, t := range b.texts {
-		util.DrawText(image, t.Str, t.Color)
-	}
+	util.DrawTexts(image, b.texts, b.direction)
 
 	return image
 }
@@ -172,12 +279,31 @@ func (b *Box) AddText(str string, color color.Color) Self {
 	return b
 }
 
+func (b *Box) SetText(str string, color color.Color) Self {
+	b.texts = []types.TextInfo{{Str: str, Color: color}}
+	return b
+}
+
+func (b *Box) ClearText() Self {
+	b.texts = []types.TextInfo{}
+	return b
+}
+
+func (b *Box) SetDirection(d types.Direction) {
+	b.direction = d
+	SetDirection(b.craft, d)
+}
+
 func (b *Box) Update(p types.Position) error {
 	return b.craft.Update(p.Add(b.margin.Pos()))
 }
 
+func (b *Box) Children() []event.Child {
+	return []event.Child{{Target: b.craft, Position: b.margin.Pos()}}
+}
+
 func (b *Box) Const() *Image {
-	return &Image{b.Image()}
+	return &Image{b.Image(), []types.TextInfo{}, b.direction}
 }
 
 // HorizontalStack Craft
@@ -188,29 +314,26 @@ func (b *Box) Const() *Image {
 // +---+---+---+
 // ```
 type HorizontalStack struct {
-	crafts []Craft
-	texts  []types.TextInfo
+	crafts    []Craft
+	texts     []types.TextInfo
+	direction types.Direction
 }
 
 func NewHorizontalStack(crafts ...Craft) *HorizontalStack {
-	return &HorizontalStack{crafts, []types.TextInfo{}}
+	return &HorizontalStack{crafts, []types.TextInfo{}, types.LeftToRight}
 }
 
 func (s *HorizontalStack) Image() *ebiten.Image {
 	size := s.Size()
 
 	image := ebiten.NewImage(size.X, size.Y)
-	x, y := 0.0, 0.0
-	for _, c := range s.crafts {
+	for i, c := range s.crafts {
 		op := &ebiten.DrawImageOptions{}
-		op.GeoM.Translate(x, y)
+		op.GeoM.Translate(float64(s.offset(i, size)), 0)
 		image.DrawImage(c.Image(), op)
-		x += float64(c.Size().X)
 	}
 
-	for _, t := range s.texts {
-		util.DrawText(image, t.Str, t.Color)
-	}
+	util.DrawTexts(image, s.texts, s.direction)
 
 	return image
 }
@@ -227,22 +350,57 @@ func (s *HorizontalStack) Size() types.Size {
 
 func (s *HorizontalStack) AddText(str string, color color.Color) Self {
 	s.texts = append(s.texts, types.TextInfo{Str: str, Color: color})
+	return s
+}
+
+func (s *HorizontalStack) SetText(str string, color color.Color) Self {
+	s.texts = []types.TextInfo{{Str: str, Color: color}}
+	return s
+}
+
+func (s *HorizontalStack) ClearText() Self {
+	s.texts = []types.TextInfo{}
+	return s
+}
+
+func (s *HorizontalStack) SetDirection(d types.Direction) {
+	s.direction = d
 	for _, c := range s.crafts {
-		c.AddText(str, color)
+		SetDirection(c, d)
 	}
-	return s
 }
 
 func (s *HorizontalStack) Update(p types.Position) (err error) {
-	for _, c := range s.crafts {
-		err = errors.Join(err, c.Update(p))
-		p.X += c.Size().X
+	size := s.Size()
+	for i, c := range s.crafts {
+		err = errors.Join(err, c.Update(p.Add(types.Position{X: s.offset(i, size)})))
 	}
 	return
 }
 
+func (s *HorizontalStack) Children() []event.Child {
+	size := s.Size()
+	children := make([]event.Child, len(s.crafts))
+	for i, c := range s.crafts {
+		children[i] = event.Child{Target: c, Position: types.Position{X: s.offset(i, size)}}
+	}
+	return children
+}
+
+// offset is the x coordinate of the i-th craft, mirrored when right-to-left.
+func (s *HorizontalStack) offset(i int, size types.Size) int {
+	x := 0
+	for _, c := range s.crafts[:i] {
+		x += c.Size().X
+	}
+	if s.direction == types.RightToLeft {
+		return size.X - x - s.crafts[i].Size().X
+	}
+	return x
+}
+
 func (s *HorizontalStack) Const() *Image {
-	return &Image{s.Image()}
+	return &Image{s.Image(), []types.TextInfo{}, s.direction}
 }
 
 // VerticalStack Craft
@@ -258,12 +416,13 @@ func (s *HorizontalStack) Const() *Image {
 //
 // ```
 type VerticalStack struct {
-	crafts []Craft
-	texts  []types.TextInfo
+	crafts    []Craft
+	texts     []types.TextInfo
+	direction types.Direction
 }
 
 func NewVerticalStack(crafts ...Craft) *VerticalStack {
-	return &VerticalStack{crafts, []types.TextInfo{}}
+	return &VerticalStack{crafts, []types.TextInfo{}, types.LeftToRight}
 }
 
 func (s *VerticalStack) Image() *ebiten.Image {
@@ -278,9 +437,7 @@ func (s *VerticalStack) Image() *ebiten.Image {
 		y += float64(c.Size().Y)
 	}
 
-	for _, t := range s.texts {
-		util.DrawText(image, t.Str, t.Color)
-	}
+	util.DrawTexts(image, s.texts, s.direction)
 
 	return image
 }
@@ -300,8 +457,25 @@ func (s *VerticalStack) AddText(str string, color color.Color) Self {
 	return s
 }
 
+func (s *VerticalStack) SetText(str string, color color.Color) Self {
+	s.texts = []types.TextInfo{{Str: str, Color: color}}
+	return s
+}
+
+func (s *VerticalStack) ClearText() Self {
+	s.texts = []types.TextInfo{}
+	return s
+}
+
+func (s *VerticalStack) SetDirection(d types.Direction) {
+	s.direction = d
+	for _, c := range s.crafts {
+		SetDirection(c, d)
+	}
+}
+
 func (s *VerticalStack) Const() *Image {
-	return &Image{s.Image()}
+	return &Image{s.Image(), []types.TextInfo{}, s.direction}
 }
 
 func (s *VerticalStack) Update(p types.Position) (err error) {
@@ -312,6 +486,16 @@ func (s *VerticalStack) Update(p types.Position) (err error) {
 	return
 }
 
+func (s *VerticalStack) Children() []event.Child {
+	children := make([]event.Child, len(s.crafts))
+	p := types.Position{}
+	for i, c := range s.crafts {
+		children[i] = event.Child{Target: c, Position: p}
+		p.Y += c.Size().Y
+	}
+	return children
+}
+
 // Layer Craft
 //
 // ```
@@ -323,12 +507,13 @@ func (s *VerticalStack) Update(p types.Position) (err error) {
 // ....+------+ --> layer
 // ```
 type Layer struct {
-	crafts []Craft
-	texts  []types.TextInfo
+	crafts    []Craft
+	texts     []types.TextInfo
+	direction types.Direction
 }
 
 func NewLayer(crafts ...Craft) *Layer {
-	return &Layer{crafts, []types.TextInfo{}}
+	return &Layer{crafts, []types.TextInfo{}, types.LeftToRight}
 }
 
 func (l *Layer) Image() *ebiten.Image {
@@ -340,9 +525,7 @@ func (l *Layer) Image() *ebiten.Image {
 		image.DrawImage(w.Image(), op)
 	}
 
-	for _, t := range l.texts {
-		util.DrawText(image, t.Str, t.Color)
-	}
+	util.DrawTexts(image, l.texts, l.direction)
 
 	return image
 }
@@ -364,8 +547,25 @@ func (l *Layer) AddText(str string, color color.Color) Self {
 	return l
 }
 
+func (l *Layer) SetText(str string, color color.Color) Self {
+	l.texts = []types.TextInfo{{Str: str, Color: color}}
+	return l
+}
+
+func (l *Layer) ClearText() Self {
+	l.texts = []types.TextInfo{}
+	return l
+}
+
+func (l *Layer) SetDirection(d types.Direction) {
+	l.direction = d
+	for _, c := range l.crafts {
+		SetDirection(c, d)
+	}
+}
+
 func (l *Layer) Const() *Image {
-	return &Image{l.Image()}
+	return &Image{l.Image(), []types.TextInfo{}, l.direction}
 }
 
 func (l *Layer) Update(p types.Position) (err error) {
@@ -374,3 +574,11 @@ func (l *Layer) Update(p types.Position) (err error) {
 	}
 	return
 }
+
+func (l *Layer) Children() []event.Child {
+	children := make([]event.Child, len(l.crafts))
+	for i, c := range l.crafts {
+		children[i] = event.Child{Target: c}
+	}
+	return children
+}
@@ -6,6 +6,7 @@ import (
 	"image/color"
 	"testing"
 
+	"github.com/a-skua/etk/craft/event"
 	"github.com/a-skua/etk/craft/types"
 )
 
@@ -264,3 +265,144 @@ func TestLayerSize(t *testing.T) {
 		})
 	}
 }
+
+func TestText(t *testing.T) {
+	newFill := func() *Fill { return NewFill(types.Size{X: 10, Y: 10}, color.White) }
+
+	img := NewImage(image.NewRGBA(image.Rect(0, 0, 10, 10)))
+	fill := newFill()
+	switcher := NewSwitch(newFill(), newFill())
+	box := NewBox(newFill(), types.MarginAll(1))
+	hChild := newFill()
+	hstack := NewHorizontalStack(hChild, newFill())
+	vChild := newFill()
+	vstack := NewVerticalStack(vChild, newFill())
+	layer := NewLayer(newFill(), newFill())
+
+	tests := []struct {
+		name  string
+		craft Craft
+		texts func() []types.TextInfo
+	}{
+		{"Image", img, func() []types.TextInfo { return img.texts }},
+		{"Fill", fill, func() []types.TextInfo { return fill.texts }},
+		{"Switch", switcher, func() []types.TextInfo { return switcher.texts }},
+		{"Box", box, func() []types.TextInfo { return box.texts }},
+		{"HorizontalStack", hstack, func() []types.TextInfo { return hstack.texts }},
+		{"VerticalStack", vstack, func() []types.TextInfo { return vstack.texts }},
+		{"Layer", layer, func() []types.TextInfo { return layer.texts }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c := tt.craft.AddText("a", color.White); c != tt.craft {
+				t.Errorf("AddText should return itself, but got %v", c)
+			}
+			tt.craft.AddText("b", color.Black)
+			if got := tt.texts(); len(got) != 2 || got[0].Str != "a" || got[1].Str != "b" {
+				t.Errorf("AddText should append texts, but got %v", got)
+			}
+
+			if c := tt.craft.SetText("c", color.White); c != tt.craft {
+				t.Errorf("SetText should return itself, but got %v", c)
+			}
+			if got := tt.texts(); len(got) != 1 || got[0].Str != "c" {
+				t.Errorf("SetText should replace texts, but got %v", got)
+			}
+
+			if c := tt.craft.ClearText(); c != tt.craft {
+				t.Errorf("ClearText should return itself, but got %v", c)
+			}
+			if got := tt.texts(); len(got) != 0 {
+				t.Errorf("ClearText should remove texts, but got %v", got)
+			}
+		})
+	}
+
+	if len(hChild.texts) != 0 || len(vChild.texts) != 0 {
+		t.Errorf("AddText should not be forwarded to children, but got %v, %v", hChild.texts, vChild.texts)
+	}
+}
+
+func TestImageAddText(t *testing.T) {
+	img := NewImage(image.NewRGBA(image.Rect(0, 0, 10, 10)))
+	src := img.image
+
+	img.AddText("a", color.White)
+	if img.image != src {
+		t.Errorf("AddText should not replace the source image")
+	}
+	if img.Image() == src {
+		t.Errorf("Image should not draw text into the source image")
+	}
+
+	img.ClearText()
+	if img.Image() != src {
+		t.Errorf("Image should return the source image without text")
+	}
+}
+
+func TestHorizontalStackUpdateRightToLeft(t *testing.T) {
+	newCraft := func(want types.Position) Craft {
+		return &testingCraft{
+			updateHandler: func(p types.Position) error {
+				if p != want {
+					t.Errorf("Update should receive %v, but got %v", want, p)
+				}
+				return nil
+			},
+			size: types.Size{X: 10, Y: 10},
+		}
+	}
+
+	stack := SetDirection(NewHorizontalStack(
+		newCraft(types.Position{X: 20, Y: 0}),
+		newCraft(types.Position{X: 10, Y: 0}),
+		newCraft(types.Position{X: 0, Y: 0}),
+	), types.RightToLeft)
+	stack.Update(types.Position{})
+}
+
+func TestSetDirection(t *testing.T) {
+	fill := NewFill(types.Size{X: 10, Y: 10}, color.White)
+	box := NewBox(fill, types.Margin{})
+	switcher := NewSwitch(box)
+	stack := NewVerticalStack(NewLayer(switcher))
+
+	SetDirection(stack, types.RightToLeft)
+	for _, d := range []types.Direction{stack.direction, switcher.direction, box.direction, fill.direction} {
+		if d != types.RightToLeft {
+			t.Errorf("SetDirection should mark the whole tree, but got %v", d)
+		}
+	}
+}
+
+func TestChildren(t *testing.T) {
+	fill := func() Craft { return NewFill(types.Size{X: 10, Y: 20}, color.White) }
+
+	tests := []struct {
+		parent event.Parent
+		want   []types.Position
+	}{
+		{NewBox(fill(), types.Margin{Top: 1, Left: 2}), []types.Position{{X: 2, Y: 1}}},
+		{NewHorizontalStack(fill(), fill()), []types.Position{{X: 0, Y: 0}, {X: 10, Y: 0}}},
+		{SetDirection(NewHorizontalStack(fill(), fill()), types.RightToLeft), []types.Position{{X: 10, Y: 0}, {X: 0, Y: 0}}},
+		{NewVerticalStack(fill(), fill()), []types.Position{{X: 0, Y: 0}, {X: 0, Y: 20}}},
+		{NewLayer(fill(), fill()), []types.Position{{X: 0, Y: 0}, {X: 0, Y: 0}}},
+		{NewSwitch(fill(), fill()), []types.Position{{X: 0, Y: 0}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			children := tt.parent.Children()
+			if len(children) != len(tt.want) {
+				t.Fatalf("Children should return %d children, but got %d", len(tt.want), len(children))
+			}
+			for i, c := range children {
+				if c.Position != tt.want[i] {
+					t.Errorf("Children[%d] should be at %v, but got %v", i, tt.want[i], c.Position)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package craft
+
+import (
+	"errors"
+	"image/color"
+	"strings"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// DialogResult tells which button closed a dialog.
+// Results other than these can be given to AddButton as well.
+type DialogResult int
+
+const (
+	// DialogCancel is the result of a dialog dismissed by going back.
+	DialogCancel DialogResult = iota
+	DialogConfirm
+)
+
+type DialogHandler func(d *Dialog, result DialogResult) error
+
+// Dialog Craft
+//
+// Dialog is a title, a body craft and a row of buttons, shown as a modal overlay by Show.
+// While it is open, the crafts below it get no input and the focus stays in it.
+// Pressing a button closes the dialog with the result of the button,
+// and going back closes it with DialogCancel.
+// The result is given to the close handler and sent to Done.
+// A dialog shown from another one stacks above it, and closing it gives the focus back.
+//
+// ```
+// +------------------------+
+// | title                  |
+// +------------------------+
+// | body                   |
+// |                        |
+// |        [ OK ] [Cancel] |
+// +------------------------+
+// ```
+type Dialog struct {
+	title      string
+	body       Craft
+	buttons    []*Button
+	color      color.Color
+	overlay    *event.Overlay
+	dispatcher *event.Dispatcher
+	result     DialogResult
+	done       chan DialogResult
+	onClose    DialogHandler
+	texts      []types.TextInfo
+	direction  types.Direction
+}
+
+const (
+	dialogPadding = 8
+	buttonPadding = 4
+)
+
+var dialogBackground = color.RGBA{0x30, 0x30, 0x30, 0xff}
+
+func NewDialog(title string, body Craft, color color.Color) *Dialog {
+	return &Dialog{
+		title: title,
+		body:  body,
+		color: color,
+		done:  make(chan DialogResult, 1),
+		texts: []types.TextInfo{},
+	}
+}
+
+// NewMessageBox returns a dialog showing message with an OK button.
+func NewMessageBox(title, message string, color color.Color) *Dialog {
+	return NewDialog(title, textCraft(message, color), color).AddButton("OK", DialogConfirm)
+}
+
+// NewConfirmDialog returns a dialog asking message with OK and Cancel buttons.
+func NewConfirmDialog(title, message string, color color.Color) *Dialog {
+	return NewDialog(title, textCraft(message, color), color).
+		AddButton("OK", DialogConfirm).
+		AddButton("Cancel", DialogCancel)
+}
+
+// textCraft is a craft drawing the lines of str.
+func textCraft(str string, clr color.Color) Craft {
+	lines := []Craft{}
+	for _, line := range strings.Split(str, "\n") {
+		size := types.Size{X: max(util.TextWidth(line), 1), Y: util.LineHeight()}
+		lines = append(lines, NewFill(size, color.Transparent).SetText(line, clr))
+	}
+	return NewVerticalStack(lines...)
+}
+
+// AddButton adds a button closing the dialog with result.
+// The buttons are laid out in the order they are added, at the end edge.
+func (d *Dialog) AddButton(label string, result DialogResult) *Dialog {
+	size := types.Size{X: util.TextWidth(label) + buttonPadding*4, Y: util.LineHeight() + buttonPadding*2}
+	b := NewButton(NewFill(size, controlBackground), func(*Button) error {
+		return d.Close(result)
+	}).SetCraft(ButtonHover, NewFill(size, controlHover)).SetLabel(label, d.color)
+	d.buttons = append(d.buttons, b)
+	return d
+}
+
+func (d *Dialog) Title() string {
+	return d.title
+}
+
+func (d *Dialog) Body() Craft {
+	return d.body
+}
+
+func (d *Dialog) OnClose(handler DialogHandler) *Dialog {
+	d.onClose = handler
+	return d
+}
+
+// Done returns a channel receiving the result each time the dialog closes,
+// so that a goroutine can wait for it.
+// A result not received by the next close is dropped.
+func (d *Dialog) Done() <-chan DialogResult {
+	return d.done
+}
+
+// Result returns the result the dialog was last closed with.
+func (d *Dialog) Result() DialogResult {
+	return d.result
+}
+
+func (d *Dialog) IsOpen() bool {
+	return d.overlay != nil
+}
+
+// Show opens the dialog above the tree and the overlays of dispatcher,
+// centered in the root, and focuses its first focusable craft.
+// It can be shown before the first Dispatch, such as from Scene.Init.
+func (d *Dialog) Show(dispatcher *event.Dispatcher) error {
+	if d.overlay != nil || dispatcher == nil {
+		return nil
+	}
+	d.dispatcher = dispatcher
+	d.overlay = &event.Overlay{
+		Target:    d,
+		Center:    true,
+		Modal:     true,
+		OnDismiss: func() error { d.overlay = nil; return d.finish(DialogCancel) },
+	}
+	return dispatcher.Open(d.overlay)
+}
+
+// Close closes the dialog with result.
+func (d *Dialog) Close(result DialogResult) error {
+	if d.overlay == nil {
+		return nil
+	}
+	overlay := d.overlay
+	d.overlay = nil
+	return errors.Join(d.dispatcher.Close(overlay), d.finish(result))
+}
+
+func (d *Dialog) finish(result DialogResult) error {
+	d.result = result
+	select {
+	case d.done <- result:
+	default:
+	}
+	if d.onClose != nil {
+		return d.onClose(d, result)
+	}
+	return nil
+}
+
+func (d *Dialog) bodyY() int {
+	return dialogPadding*2 + util.LineHeight()
+}
+
+func (d *Dialog) buttonsY() int {
+	return d.bodyY() + d.body.Size().Y + dialogPadding
+}
+
+func (d *Dialog) buttonsSize() types.Size {
+	size := types.Size{}
+	for i, b := range d.buttons {
+		s := b.Size()
+		size.X += s.X
+		size.Y = max(size.Y, s.Y)
+		if i > 0 {
+			size.X += labelGap
+		}
+	}
+	return size
+}
+
+func (d *Dialog) Image() *ebiten.Image {
+	size := d.Size()
+	image := ebiten.NewImage(size.X, size.Y)
+	image.Fill(dialogBackground)
+	vector.StrokeRect(image, 0.5, 0.5, float32(size.X)-1, float32(size.Y)-1, 1, controlBorder, false)
+
+	titleAt := types.Position{X: dialogPadding, Y: dialogPadding}
+	if d.direction == types.RightToLeft {
+		titleAt.X = size.X - dialogPadding - util.TextWidth(d.title)
+	}
+	util.DrawTextAt(image, d.title, d.color, titleAt)
+	line := float32(d.bodyY() - dialogPadding/2)
+	vector.StrokeLine(image, 0, line, float32(size.X), line, 1, controlBorder, false)
+
+	for _, c := range d.Children() {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(c.Position.X), float64(c.Position.Y))
+		image.DrawImage(c.Target.(Craft).Image(), op)
+	}
+	util.DrawTexts(image, d.texts, d.direction)
+
+	return image
+}
+
+func (d *Dialog) Size() types.Size {
+	buttons := d.buttonsSize()
+	width := max(util.TextWidth(d.title), d.body.Size().X, buttons.X)
+	return types.Size{
+		X: width + dialogPadding*2,
+		Y: d.buttonsY() + buttons.Y + dialogPadding,
+	}
+}
+
+// Children are the body, aligned to the start edge,
+// and the buttons, aligned to the end edge.
+func (d *Dialog) Children() []event.Child {
+	width := d.Size().X
+	body := types.Position{X: dialogPadding, Y: d.bodyY()}
+	if d.direction == types.RightToLeft {
+		body.X = width - dialogPadding - d.body.Size().X
+	}
+	children := []event.Child{{Target: d.body, Position: body}}
+
+	x, y := width-dialogPadding-d.buttonsSize().X, d.buttonsY()
+	for _, b := range d.buttons {
+		p := types.Position{X: x, Y: y}
+		if d.direction == types.RightToLeft {
+			p.X = width - x - b.Size().X
+		}
+		children = append(children, event.Child{Target: b, Position: p})
+		x += b.Size().X + labelGap
+	}
+	return children
+}
+
+func (d *Dialog) AddText(str string, color color.Color) Self {
+	d.texts = append(d.texts, types.TextInfo{Str: str, Color: color})
+	return d
+}
+
+func (d *Dialog) SetText(str string, color color.Color) Self {
+	d.texts = []types.TextInfo{{Str: str, Color: color}}
+	return d
+}
+
+func (d *Dialog) ClearText() Self {
+	d.texts = []types.TextInfo{}
+	return d
+}
+
+func (d *Dialog) SetDirection(dir types.Direction) {
+	d.direction = dir
+	SetDirection(d.body, dir)
+	for _, b := range d.buttons {
+		b.SetDirection(dir)
+	}
+}
+
+func (d *Dialog) Const() *Image {
+	return &Image{d.Image(), []types.TextInfo{}, d.direction}
+}
+
+func (d *Dialog) Update(p types.Position) (err error) {
+	for _, c := range d.Children() {
+		err = errors.Join(err, c.Target.(Craft).Update(p.Add(c.Position)))
+	}
+	return err
+}
@@ -0,0 +1,153 @@
+package craft
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ Craft = NewMessageBox("title", "message", color.White)
+
+func TestDialogShow(t *testing.T) {
+	clicks := 0
+	below := NewButton(NewFill(types.Size{X: 300, Y: 200}, color.Black), func(*Button) error { clicks++; return nil })
+	in := input.New()
+	d := event.NewDispatcher()
+	press := func(keys ...ebiten.Key) {
+		in.Update(input.State{Keys: keys})
+		d.Dispatch(below, in)
+		in.Update(input.State{})
+		d.Dispatch(below, in)
+	}
+
+	press(ebiten.KeyTab)
+	results := []DialogResult{}
+	dialog := NewConfirmDialog("title", "message", color.White).
+		OnClose(func(_ *Dialog, r DialogResult) error { results = append(results, r); return nil })
+	dialog.Show(d)
+
+	size := dialog.Size()
+	if want := (types.Position{X: (300 - size.X) / 2, Y: (200 - size.Y) / 2}); d.Overlays()[0].Position != want {
+		t.Errorf("Show should center the dialog at %v, but got %v", want, d.Overlays()[0].Position)
+	}
+
+	ok, cancel := dialog.buttons[0], dialog.buttons[1]
+	tests := []struct {
+		press func()
+		focus event.Target
+	}{
+		{func() {}, ok},
+		{func() { press(ebiten.KeyTab) }, cancel},
+		{func() { press(ebiten.KeyTab) }, ok},
+		{func() { press(ebiten.KeyShift, ebiten.KeyTab) }, cancel},
+		// the button below is neither clicked nor focused
+		{func() { click(d, below, in, types.Position{X: 1, Y: 1}) }, cancel},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			tt.press()
+			if d.Focused() != tt.focus {
+				t.Errorf("Focused should return %v, but got %v", tt.focus, d.Focused())
+			}
+		})
+	}
+
+	press(ebiten.KeyArrowLeft)
+	press(ebiten.KeyEnter)
+	if dialog.IsOpen() || len(d.Overlays()) != 0 {
+		t.Errorf("Enter on OK should close the dialog")
+	}
+	if r := <-dialog.Done(); r != DialogConfirm {
+		t.Errorf("Done should receive %v, but got %v", DialogConfirm, r)
+	}
+	if d.Focused() != below {
+		t.Errorf("Focused should return the button below, but got %v", d.Focused())
+	}
+
+	dialog.Show(d)
+	press(ebiten.KeyEscape)
+	if dialog.IsOpen() || dialog.Result() != DialogCancel {
+		t.Errorf("Escape should cancel the dialog, but got %v", dialog.Result())
+	}
+	if fmt.Sprint(results) != fmt.Sprint([]DialogResult{DialogConfirm, DialogCancel}) {
+		t.Errorf("handler should be called with confirm and cancel, but got %v", results)
+	}
+	if clicks != 0 || d.Back() {
+		t.Errorf("the input should not reach below the dialog, but got %d clicks", clicks)
+	}
+}
+
+func TestDialogStack(t *testing.T) {
+	root := NewFill(types.Size{X: 300, Y: 200}, color.Black)
+	in := input.New()
+	d := event.NewDispatcher()
+	d.Dispatch(root, in)
+
+	second := NewMessageBox("second", "message", color.White)
+	first := NewDialog("first", NewFill(types.Size{X: 50, Y: 20}, color.White), color.White).
+		AddButton("More", DialogConfirm+1).
+		OnClose(func(*Dialog, DialogResult) error { return nil })
+	first.buttons[0].OnClick(func(*Button) error { return second.Show(d) })
+
+	first.Show(d)
+	d.Activate()
+	if len(d.Overlays()) != 2 || d.Focused() != second.buttons[0] {
+		t.Fatalf("the second dialog should be focused above the first one, but got %v", d.Focused())
+	}
+
+	in.Update(input.State{Keys: []ebiten.Key{ebiten.KeyEnter}})
+	d.Dispatch(root, in)
+	if !first.IsOpen() || second.IsOpen() {
+		t.Errorf("only the second dialog should be closed")
+	}
+	if d.Focused() != first.buttons[0] {
+		t.Errorf("Focused should return the button of the first dialog, but got %v", d.Focused())
+	}
+
+	// closing the first dialog cancels the second one above it
+	results := []DialogResult{}
+	second.OnClose(func(_ *Dialog, r DialogResult) error { results = append(results, r); return nil })
+	second.Show(d)
+	first.Close(DialogConfirm)
+	if first.IsOpen() || second.IsOpen() || len(d.Overlays()) != 0 {
+		t.Errorf("both dialogs should be closed, but got %d overlays", len(d.Overlays()))
+	}
+	if fmt.Sprint(results) != fmt.Sprint([]DialogResult{DialogCancel}) {
+		t.Errorf("the second dialog should be canceled, but got %v", results)
+	}
+	if second.Show(d); !second.IsOpen() {
+		t.Errorf("the second dialog should show again")
+	}
+}
+
+func TestDialogShowBeforeDispatch(t *testing.T) {
+	in := input.New()
+	d := event.NewDispatcher()
+	dialog := NewMessageBox("title", "message", color.White)
+	dialog.Show(d)
+
+	tests := []struct {
+		root types.Size
+	}{
+		{types.Size{X: 300, Y: 200}},
+		{types.Size{X: 400, Y: 300}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			// the dialog stays centered when the root is resized as well
+			d.Dispatch(NewFill(tt.root, color.Black), in)
+			size := dialog.Size()
+			want := types.Position{X: (tt.root.X - size.X) / 2, Y: (tt.root.Y - size.Y) / 2}
+			if p := d.Overlays()[0].Position; p != want {
+				t.Errorf("the dialog should be centered at %v, but got %v", want, p)
+			}
+		})
+	}
+}
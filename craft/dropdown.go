@@ -0,0 +1,259 @@
+package craft
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type DropdownHandler func(*Dropdown) error
+
+// Dropdown Craft
+//
+// Dropdown shows the selected option, and opens a list of the options in an overlay
+// when clicked or activated, so the list is drawn above every craft.
+// Clicking an option or activating it with the keyboard selects it and closes the list;
+// a press outside of the list or going back closes it unchanged.
+//
+// ```
+// +------------+-+
+// | second     |v|
+// +------------+-+
+// | first        |
+// |[second      ]|
+// | third        |
+// +--------------+
+// ```
+type Dropdown struct {
+	width      int
+	color      color.Color
+	options    []string
+	selected   int
+	disabled   bool
+	popup      *event.Overlay
+	dispatcher *event.Dispatcher
+	onChange   DropdownHandler
+	texts      []types.TextInfo
+	direction  types.Direction
+}
+
+const (
+	dropdownPadding = 2
+	// dropdownRows is the number of options shown before the list scrolls.
+	dropdownRows = 8
+)
+
+func NewDropdown(width int, color color.Color, options ...string) *Dropdown {
+	return &Dropdown{
+		width:   width,
+		color:   color,
+		options: options,
+		texts:   []types.TextInfo{},
+	}
+}
+
+// Selected returns the index of the selected option, or -1 without options.
+func (d *Dropdown) Selected() int {
+	if len(d.options) == 0 {
+		return -1
+	}
+	return d.selected
+}
+
+// Value returns the selected option.
+func (d *Dropdown) Value() string {
+	if len(d.options) == 0 {
+		return ""
+	}
+	return d.options[d.selected]
+}
+
+// Select selects the option i without calling the change handler.
+// Indexes out of range are ignored.
+func (d *Dropdown) Select(i int) *Dropdown {
+	if 0 <= i && i < len(d.options) {
+		d.selected = i
+	}
+	return d
+}
+
+// SetDisabled disables the dropdown. The list stays open until it is closed.
+func (d *Dropdown) SetDisabled(disabled bool) *Dropdown {
+	d.disabled = disabled
+	return d
+}
+
+func (d *Dropdown) Disabled() bool {
+	return d.disabled
+}
+
+func (d *Dropdown) OnChange(handler DropdownHandler) *Dropdown {
+	d.onChange = handler
+	return d
+}
+
+// IsOpen reports whether the list is shown.
+func (d *Dropdown) IsOpen() bool {
+	return d.popup != nil
+}
+
+// list builds the list of the options, as wide as the dropdown.
+func (d *Dropdown) list() *ListView {
+	height := util.LineHeight() + dropdownPadding*2
+	size := types.Size{X: d.width, Y: height}
+	l := NewListView(
+		types.Size{X: d.width, Y: min(len(d.options), dropdownRows) * height},
+		height,
+		ListFunc{
+			Count: func() int { return len(d.options) },
+			Build: func(i int) Craft {
+				return NewButton(NewFill(size, controlBackground).SetText(d.options[i], d.color), func(*Button) error {
+					return d.choose(i)
+				}).SetCraft(ButtonHover, NewFill(size, controlHover).SetText(d.options[i], d.color))
+			},
+		},
+	)
+	return l.SetSelection(SelectNone).
+		ScrollTo(d.selected).
+		OnActivate(func(_ *ListView, i int) error { return d.choose(i) })
+}
+
+// open shows the list below the dropdown, at p.
+func (d *Dropdown) open(dispatcher *event.Dispatcher, p types.Position) error {
+	if dispatcher == nil || len(d.options) == 0 {
+		return nil
+	}
+	d.dispatcher = dispatcher
+	d.popup = &event.Overlay{
+		Target:    SetDirection(d.list(), d.direction),
+		Position:  p.Add(types.Position{Y: d.Size().Y}),
+		Owner:     d,
+		OnDismiss: func() error { d.popup = nil; return nil },
+	}
+	return dispatcher.Open(d.popup)
+}
+
+// Close closes the list.
+func (d *Dropdown) Close() error {
+	if d.popup == nil {
+		return nil
+	}
+	popup := d.popup
+	d.popup = nil
+	return d.dispatcher.Close(popup)
+}
+
+// choose selects the option i, closes the list, and calls the change handler if the selection changed.
+func (d *Dropdown) choose(i int) error {
+	err := d.Close()
+	if i == d.selected {
+		return err
+	}
+	d.selected = i
+	if d.onChange != nil {
+		err = errors.Join(err, d.onChange(d))
+	}
+	return err
+}
+
+func (d *Dropdown) Image() *ebiten.Image {
+	size := d.Size()
+	image := ebiten.NewImage(size.X, size.Y)
+	image.Fill(controlBackground)
+
+	border := controlBorder
+	if d.popup != nil {
+		border = controlAccent
+	}
+	vector.StrokeRect(image, 0.5, 0.5, float32(size.X)-1, float32(size.Y)-1, 1, border, false)
+
+	// The arrow is at the end edge, and the text at the start edge.
+	arrow := float32(size.X - size.Y/2 - dropdownPadding)
+	textAt := types.Position{X: dropdownPadding, Y: dropdownPadding}
+	if d.direction == types.RightToLeft {
+		arrow = float32(size.Y/2 + dropdownPadding)
+		textAt.X = size.X - dropdownPadding - util.TextWidth(d.Value())
+	}
+	mid, h := float32(size.Y)/2, float32(size.Y)/6
+	vector.StrokeLine(image, arrow-h, mid-h/2, arrow, mid+h/2, 1, d.color, true)
+	vector.StrokeLine(image, arrow, mid+h/2, arrow+h, mid-h/2, 1, d.color, true)
+
+	util.DrawTextAt(image, d.Value(), d.color, textAt)
+	if d.disabled {
+		vector.DrawFilledRect(image, 0, 0, float32(size.X), float32(size.Y), controlDisabled, false)
+	}
+	util.DrawTexts(image, d.texts, d.direction)
+
+	return image
+}
+
+func (d *Dropdown) Size() types.Size {
+	return types.Size{X: d.width, Y: util.LineHeight() + dropdownPadding*2}
+}
+
+func (d *Dropdown) AddText(str string, color color.Color) Self {
+	d.texts = append(d.texts, types.TextInfo{Str: str, Color: color})
+	return d
+}
+
+func (d *Dropdown) SetText(str string, color color.Color) Self {
+	d.texts = []types.TextInfo{{Str: str, Color: color}}
+	return d
+}
+
+func (d *Dropdown) ClearText() Self {
+	d.texts = []types.TextInfo{}
+	return d
+}
+
+func (d *Dropdown) SetDirection(dir types.Direction) {
+	d.direction = dir
+}
+
+func (d *Dropdown) Const() *Image {
+	return &Image{d.Image(), []types.TextInfo{}, d.direction}
+}
+
+func (d *Dropdown) Update(p types.Position) error {
+	return nil
+}
+
+func (d *Dropdown) Focusable() bool {
+	return !d.disabled
+}
+
+func (d *Dropdown) CursorShape() ebiten.CursorShapeType {
+	if d.disabled {
+		return ebiten.CursorShapeNotAllowed
+	}
+	return ebiten.CursorShapePointer
+}
+
+func (d *Dropdown) HandleEvent(e *event.Event) error {
+	if d.disabled {
+		return nil
+	}
+	switch e.Type {
+	case event.PointerDown:
+		if e.Button != ebiten.MouseButtonLeft {
+			return nil
+		}
+		e.StopPropagation()
+		if d.popup != nil {
+			return d.Close()
+		}
+		return d.open(e.Dispatcher(), e.Position.Sub(e.Local()))
+	case event.Activate:
+		e.StopPropagation()
+		if d.popup != nil {
+			return nil
+		}
+		return d.open(e.Dispatcher(), e.Position.Sub(e.Local()))
+	}
+	return nil
+}
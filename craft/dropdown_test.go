@@ -0,0 +1,87 @@
+package craft
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ Craft = NewDropdown(100, color.White, "a", "b")
+
+func TestDropdownPointer(t *testing.T) {
+	changes := []string{}
+	dd := NewDropdown(100, color.White, "first", "second", "third").
+		OnChange(func(d *Dropdown) error { changes = append(changes, d.Value()); return nil })
+	root := NewVerticalStack(dd, NewFill(types.Size{X: 100, Y: 100}, color.Black))
+	in := input.New()
+	d := event.NewDispatcher()
+
+	// options are 20 high below the dropdown, which is 20 high
+	tests := []struct {
+		press types.Position
+		open  bool
+		want  string
+	}{
+		{types.Position{X: 5, Y: 5}, true, "first"},
+		{types.Position{X: 5, Y: 45}, false, "second"},
+		{types.Position{X: 5, Y: 5}, true, "second"},
+		{types.Position{X: 5, Y: 5}, false, "second"},
+		{types.Position{X: 5, Y: 5}, true, "second"},
+		// outside of the list
+		{types.Position{X: 5, Y: 110}, false, "second"},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			click(d, root, in, tt.press)
+			if dd.IsOpen() != tt.open || len(d.Overlays()) > 0 != tt.open {
+				t.Errorf("IsOpen should return %v, but got %v", tt.open, dd.IsOpen())
+			}
+			if dd.Value() != tt.want {
+				t.Errorf("Value should return %q, but got %q", tt.want, dd.Value())
+			}
+		})
+	}
+
+	if fmt.Sprint(changes) != "[second]" {
+		t.Errorf("handler should be called with [second], but got %v", changes)
+	}
+}
+
+func TestDropdownKeys(t *testing.T) {
+	dd := NewDropdown(100, color.White, "first", "second", "third").Select(1)
+	in := input.New()
+	d := event.NewDispatcher()
+	press := func(k ebiten.Key) {
+		in.Update(input.State{Keys: []ebiten.Key{k}})
+		d.Dispatch(dd, in)
+		in.Update(input.State{})
+		d.Dispatch(dd, in)
+	}
+
+	press(ebiten.KeyTab)
+	press(ebiten.KeyEnter)
+	if !dd.IsOpen() {
+		t.Fatalf("Enter should open the list")
+	}
+	press(ebiten.KeyArrowDown)
+	press(ebiten.KeyEnter)
+	if dd.IsOpen() || dd.Value() != "third" {
+		t.Errorf("Enter should select third and close the list, but got %q and %v", dd.Value(), dd.IsOpen())
+	}
+	if d.Focused() != dd {
+		t.Errorf("Focused should return the dropdown, but got %v", d.Focused())
+	}
+
+	press(ebiten.KeyEnter)
+	press(ebiten.KeyArrowUp)
+	press(ebiten.KeyEscape)
+	if dd.IsOpen() || dd.Value() != "third" || d.Back() {
+		t.Errorf("Escape should close the list unchanged, but got %q and %v", dd.Value(), dd.IsOpen())
+	}
+}
@@ -0,0 +1,409 @@
+package event
+
+import (
+	"errors"
+
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// node is a target and its absolute position.
+type node struct {
+	target   Target
+	position types.Position
+}
+
+// path is a chain of nodes from the root to a target.
+type path []node
+
+func (p path) contains(target Target) bool {
+	for _, n := range p {
+		if n.target == target {
+			return true
+		}
+	}
+	return false
+}
+
+func (p path) last() Target {
+	if len(p) == 0 {
+		return nil
+	}
+	return p[len(p)-1].target
+}
+
+// hitTest returns the path to the deepest target at p.
+// Later children are drawn on top, so they are tested first.
+func hitTest(n node, p types.Position) path {
+	local := p.Sub(n.position)
+	size := n.target.Size()
+	if local.X < 0 || local.Y < 0 || local.X >= size.X || local.Y >= size.Y {
+		return nil
+	}
+
+	result := path{n}
+	if parent, ok := n.target.(Parent); ok {
+		children := parent.Children()
+		for i := len(children) - 1; i >= 0; i-- {
+			c := children[i]
+			if sub := hitTest(node{c.Target, n.position.Add(c.Position)}, p); sub != nil {
+				return append(result, sub...)
+			}
+		}
+	}
+	return result
+}
+
+// find returns the path to target, or nil if it is not in the tree.
+func find(n node, target Target) path {
+	if n.target == target {
+		return path{n}
+	}
+	if parent, ok := n.target.(Parent); ok {
+		for _, c := range parent.Children() {
+			if sub := find(node{c.Target, n.position.Add(c.Position)}, target); sub != nil {
+				return append(path{n}, sub...)
+			}
+		}
+	}
+	return nil
+}
+
+// Dispatcher delivers the input of each tick to a craft tree as events.
+//
+// Pointer events go to the deepest craft under the cursor.
+// While a button is held, pointer events go to the craft it was pressed on.
+// Each touch is a pointer of its own, going to the craft it started on.
+// Key and text events go to the focused craft, or to the root when nothing is focused.
+// Pressing a craft focuses its deepest Focusable craft, and keys no craft stopped
+// move the focus or activate the focused craft; see navigate.
+// Which inputs do so is decided by an action map; see SetActions.
+// PointerEnter and PointerLeave are sent to each craft the pointer enters or leaves,
+// without capture or bubble.
+// While a button is held, a craft can also start a drag session; see Drag.
+// Overlays such as popups are shown above the tree; see Overlay.
+type Dispatcher struct {
+	root    node
+	capture Target
+	focus   Target
+	hover   path
+	cursor  ebiten.CursorShapeType
+	drag    *Drag
+	touches map[ebiten.TouchID]Target
+	visible bool
+	back    bool
+	actions *input.Map
+
+	overlays []*Overlay
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{touches: map[ebiten.TouchID]Target{}, actions: input.DefaultMap()}
+}
+
+// SetActions sets the map of the confirm, back and direction actions used to navigate.
+// The default is input.DefaultMap.
+func (d *Dispatcher) SetActions(m *input.Map) *Dispatcher {
+	d.actions = m
+	return d
+}
+
+func (d *Dispatcher) Actions() *input.Map {
+	return d.actions
+}
+
+// Hovered returns the crafts under the pointer from the root to the deepest one.
+func (d *Dispatcher) Hovered() []Target {
+	targets := make([]Target, len(d.hover))
+	for i, n := range d.hover {
+		targets[i] = n.target
+	}
+	return targets
+}
+
+// CursorShape is the shape requested by the deepest craft under the pointer implementing Cursor.
+// While a button is held, the craft it was pressed on decides.
+func (d *Dispatcher) CursorShape() ebiten.CursorShapeType {
+	return d.cursor
+}
+
+// Focused returns the focused craft, or nil.
+func (d *Dispatcher) Focused() Target {
+	return d.focus
+}
+
+// Dispatch delivers the input of a tick to the tree of root.
+func (d *Dispatcher) Dispatch(root Target, in *input.Input) (err error) {
+	r := node{root, types.Position{}}
+	d.root = r
+	d.back = false
+	for _, o := range d.overlays {
+		if !o.placed || o.Center {
+			d.fit(o)
+		}
+	}
+	mods := modifiers(in)
+	newEvent := func(t Type) *Event {
+		return &Event{Type: t, Position: in.Cursor(), Modifiers: mods}
+	}
+
+	hit := d.hitTest(in.Cursor())
+	pointer := func() path {
+		if d.capture != nil {
+			if p := d.find(d.capture); p != nil {
+				return p
+			}
+		}
+		return hit
+	}
+
+	err = errors.Join(err, d.setHover(hit, newEvent))
+	if in.CursorMoved() {
+		err = errors.Join(err, d.move(pointer(), hit, newEvent(PointerMove)))
+	}
+
+	for _, b := range in.Buttons() {
+		if !in.IsButtonJustPressed(b) {
+			continue
+		}
+		err = errors.Join(err, d.dismissOutside(hit))
+		d.capture = hit.last()
+		d.visible = false
+		err = errors.Join(err, d.pressFocus(hit))
+		e := newEvent(PointerDown)
+		e.Button = b
+		err = errors.Join(err, d.dispatch(hit, e))
+	}
+
+	for _, b := range in.JustReleasedButtons() {
+		if d.drag != nil && released(in) {
+			e := newEvent(Drop)
+			e.Button = b
+			err = errors.Join(err, d.dispatch(hit, e))
+		}
+		e := newEvent(PointerUp)
+		e.Button = b
+		err = errors.Join(err, d.dispatch(pointer(), e))
+	}
+	if len(in.Buttons()) == 0 {
+		d.capture = nil
+	}
+
+	err = errors.Join(err, d.dispatchTouches(in, mods))
+	if released(in) {
+		d.drag = nil
+	}
+	d.cursor = cursorShape(pointer())
+
+	if x, y := in.Wheel(); x != 0 || y != 0 {
+		e := newEvent(Wheel)
+		e.WheelX, e.WheelY = x, y
+		err = errors.Join(err, d.dispatch(hit, e))
+	}
+
+	for _, k := range in.Keys() {
+		if !in.IsKeyRepeated(k) {
+			continue
+		}
+		e := newEvent(KeyDown)
+		e.Key = k
+		e.Repeat = !in.IsKeyJustPressed(k)
+		err = errors.Join(err, d.dispatch(d.focusPath(r), e))
+		if !e.Stopped() {
+			err = errors.Join(err, d.navigate(e))
+		}
+	}
+	err = errors.Join(err, d.dispatchGamepad(r, in, newEvent))
+
+	focus := d.focusPath(r)
+	for _, k := range in.JustReleasedKeys() {
+		e := newEvent(KeyUp)
+		e.Key = k
+		err = errors.Join(err, d.dispatch(focus, e))
+	}
+	if chars := in.Chars(); len(chars) > 0 {
+		e := newEvent(Text)
+		e.Chars = chars
+		err = errors.Join(err, d.dispatch(focus, e))
+	}
+	if in.CompositionChanged() {
+		e := newEvent(Compose)
+		e.Chars = []rune(in.Composition())
+		err = errors.Join(err, d.dispatch(focus, e))
+	}
+
+	return err
+}
+
+// move dispatches a PointerMove to p.
+// Drop targets under a dragged craft follow the pointer as well, through hit.
+func (d *Dispatcher) move(p, hit path, e *Event) error {
+	if d.drag != nil {
+		d.drag.position = e.Position
+	}
+	err := d.dispatch(p, e)
+	if d.drag != nil && hit.last() != p.last() {
+		e := *e
+		e.stopped = false
+		err = errors.Join(err, d.dispatch(hit, &e))
+	}
+	return err
+}
+
+// dispatchTouches dispatches the touches as pointer events with the left button.
+func (d *Dispatcher) dispatchTouches(in *input.Input, mods Modifier) (err error) {
+	newEvent := func(t Type, touch input.Touch) *Event {
+		return &Event{Type: t, Position: touch.Position, Button: ebiten.MouseButtonLeft, Touch: true, TouchID: touch.ID, Modifiers: mods}
+	}
+	touchPath := func(touch input.Touch) path {
+		if target := d.touches[touch.ID]; target != nil {
+			if p := d.find(target); p != nil {
+				return p
+			}
+		}
+		return d.hitTest(touch.Position)
+	}
+
+	for _, touch := range in.Touches() {
+		switch {
+		case in.IsTouchJustPressed(touch.ID):
+			hit := d.hitTest(touch.Position)
+			err = errors.Join(err, d.dismissOutside(hit))
+			d.touches[touch.ID] = hit.last()
+			d.visible = false
+			err = errors.Join(err, d.pressFocus(hit))
+			err = errors.Join(err, d.dispatch(hit, newEvent(PointerDown, touch)))
+		case in.TouchMoved(touch):
+			err = errors.Join(err, d.move(touchPath(touch), d.hitTest(touch.Position), newEvent(PointerMove, touch)))
+		}
+	}
+
+	for _, touch := range in.JustReleasedTouches() {
+		if d.drag != nil && released(in) {
+			err = errors.Join(err, d.dispatch(d.hitTest(touch.Position), newEvent(Drop, touch)))
+		}
+		err = errors.Join(err, d.dispatch(touchPath(touch), newEvent(PointerUp, touch)))
+		delete(d.touches, touch.ID)
+	}
+	return err
+}
+
+// released reports whether no button or touch is held.
+func released(in *input.Input) bool {
+	return len(in.Buttons()) == 0 && len(in.Touches()) == 0
+}
+
+// setHover sends PointerLeave to the crafts no longer under the pointer, deepest first,
+// then PointerEnter to the crafts newly under it, outermost first.
+func (d *Dispatcher) setHover(hit path, newEvent func(Type) *Event) (err error) {
+	old := d.hover
+	d.hover = hit
+
+	for i := len(old) - 1; i >= 0; i-- {
+		if !hit.contains(old[i].target) {
+			err = errors.Join(err, d.dispatch(old[:i+1], newEvent(PointerLeave)))
+		}
+	}
+	for i := range hit {
+		if !old.contains(hit[i].target) {
+			err = errors.Join(err, d.dispatch(hit[:i+1], newEvent(PointerEnter)))
+		}
+	}
+	return err
+}
+
+func cursorShape(p path) ebiten.CursorShapeType {
+	for i := len(p) - 1; i >= 0; i-- {
+		if c, ok := p[i].target.(Cursor); ok && c.CursorShape() != ebiten.CursorShapeDefault {
+			return c.CursorShape()
+		}
+	}
+	return ebiten.CursorShapeDefault
+}
+
+// focusPath returns the path to the focused craft, or to the root of the top modal overlay or the tree.
+// A focused craft removed from the tree is blurred.
+func (d *Dispatcher) focusPath(r node) path {
+	if d.focus != nil {
+		if p := d.find(d.focus); p != nil {
+			return p
+		}
+		d.setFocus(nil)
+	}
+	if o := d.modal(); o != nil {
+		return path{o.node()}
+	}
+	return path{r}
+}
+
+// setFocus moves the focus to target, sending Blur and Focus to the old and new targets only.
+func (d *Dispatcher) setFocus(target Target) (err error) {
+	if d.focus == target {
+		return nil
+	}
+	old := d.focus
+	d.focus = target
+	if old != nil {
+		err = errors.Join(err, d.dispatch(path{{old, types.Position{}}}, &Event{Type: Blur}))
+	}
+	if target != nil {
+		err = errors.Join(err, d.dispatch(d.find(target), &Event{Type: Focus}))
+	}
+	return err
+}
+
+// dispatch delivers e along p: capture from the root, the target, then bubble to the root.
+func (d *Dispatcher) dispatch(p path, e *Event) (err error) {
+	if len(p) == 0 {
+		return nil
+	}
+	e.target = p.last()
+	e.dispatcher = d
+
+	switch e.Type {
+	case Focus, Blur, PointerEnter, PointerLeave:
+		p = p[len(p)-1:]
+	}
+
+	deliver := func(n node, phase Phase) bool {
+		if l, ok := n.target.(Listener); ok {
+			e.Phase = phase
+			e.current = n
+			err = errors.Join(err, l.HandleEvent(e))
+		}
+		return e.stopped
+	}
+
+	for _, n := range p[:len(p)-1] {
+		if deliver(n, Capture) {
+			return
+		}
+	}
+	if deliver(p[len(p)-1], AtTarget) {
+		return
+	}
+	for i := len(p) - 2; i >= 0; i-- {
+		if deliver(p[i], Bubble) {
+			return
+		}
+	}
+	return
+}
+
+func modifiers(in *input.Input) Modifier {
+	var m Modifier
+	if in.IsKeyPressed(ebiten.KeyShift) {
+		m |= Shift
+	}
+	if in.IsKeyPressed(ebiten.KeyControl) {
+		m |= Control
+	}
+	if in.IsKeyPressed(ebiten.KeyAlt) {
+		m |= Alt
+	}
+	if in.IsKeyPressed(ebiten.KeyMeta) {
+		m |= Meta
+	}
+	return m
+}
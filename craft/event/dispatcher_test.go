@@ -0,0 +1,297 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// testingTarget records the events it receives.
+type testingTarget struct {
+	name     string
+	size     types.Size
+	children []Child
+	log      *[]string
+	stop     Phase
+	stopType Type
+	disabled bool
+}
+
+func (t *testingTarget) Size() types.Size {
+	return t.size
+}
+
+func (t *testingTarget) Focusable() bool {
+	return !t.disabled
+}
+
+func (t *testingTarget) Children() []Child {
+	return t.children
+}
+
+func (t *testingTarget) HandleEvent(e *Event) error {
+	*t.log = append(*t.log, fmt.Sprintf("%s:%d:%d", t.name, e.Type, e.Phase))
+	if t.stopType == e.Type && t.stop == e.Phase {
+		e.StopPropagation()
+	}
+	return nil
+}
+
+// without removes the events of types ts from log.
+func without(log []string, ts ...Type) []string {
+	result := []string{}
+	for _, l := range log {
+		found := false
+		for _, t := range ts {
+			found = found || strings.Contains(l, fmt.Sprintf(":%d:", t))
+		}
+		if !found {
+			result = append(result, l)
+		}
+	}
+	return result
+}
+
+// tree builds
+//
+// ```
+// root (0,0 100x100)
+// +-- a (0,0 50x50)
+// +-- b (50,0 50x50)
+//
+//	+-- c (10,10 20x20)
+//
+// ```
+func tree(log *[]string) (root, a, b, c *testingTarget) {
+	c = &testingTarget{name: "c", size: types.Size{X: 20, Y: 20}, log: log, stop: -1}
+	b = &testingTarget{name: "b", size: types.Size{X: 50, Y: 50}, log: log, stop: -1,
+		children: []Child{{c, types.Position{X: 10, Y: 10}}}}
+	a = &testingTarget{name: "a", size: types.Size{X: 50, Y: 50}, log: log, stop: -1}
+	root = &testingTarget{name: "root", size: types.Size{X: 100, Y: 100}, log: log, stop: -1,
+		children: []Child{{a, types.Position{}}, {b, types.Position{X: 50}}}}
+	return
+}
+
+func TestHitTest(t *testing.T) {
+	root, a, b, c := tree(&[]string{})
+
+	tests := []struct {
+		position types.Position
+		want     []Target
+	}{
+		{types.Position{X: 10, Y: 10}, []Target{root, a}},
+		{types.Position{X: 55, Y: 5}, []Target{root, b}},
+		{types.Position{X: 65, Y: 15}, []Target{root, b, c}},
+		{types.Position{X: 10, Y: 60}, []Target{root}},
+		{types.Position{X: 100, Y: 0}, nil},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			got := hitTest(node{root, types.Position{}}, tt.position)
+			if len(got) != len(tt.want) {
+				t.Fatalf("hitTest should return %d nodes, but got %d", len(tt.want), len(got))
+			}
+			for i, n := range got {
+				if n.target != tt.want[i] {
+					t.Errorf("hitTest[%d] should return %v, but got %v", i, tt.want[i], n.target)
+				}
+			}
+		})
+	}
+}
+
+func TestDispatchPhases(t *testing.T) {
+	tests := []struct {
+		stop  string
+		phase Phase
+		want  []string
+	}{
+		{"", -1, []string{"root:0:0", "b:0:0", "c:0:1", "b:0:2", "root:0:2"}},
+		{"b", Capture, []string{"root:0:0", "b:0:0"}},
+		{"c", AtTarget, []string{"root:0:0", "b:0:0", "c:0:1"}},
+		{"b", Bubble, []string{"root:0:0", "b:0:0", "c:0:1", "b:0:2"}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			log := []string{}
+			root, _, b, c := tree(&log)
+			switch tt.stop {
+			case "b":
+				b.stop = tt.phase
+			case "c":
+				c.stop = tt.phase
+			}
+
+			in := input.New()
+			in.Update(input.State{Cursor: types.Position{X: 65, Y: 15}})
+			in.Update(input.State{Cursor: types.Position{X: 65, Y: 15}, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}})
+
+			log = log[:0]
+			if err := NewDispatcher().Dispatch(root, in); err != nil {
+				t.Fatal(err)
+			}
+
+			// Focus is delivered before PointerDown.
+			got := without(log, PointerEnter, PointerLeave)[1:]
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Dispatch should deliver %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDispatchCapture(t *testing.T) {
+	log := []string{}
+	root, _, b, _ := tree(&log)
+	d := NewDispatcher()
+	in := input.New()
+
+	pressed := []ebiten.MouseButton{ebiten.MouseButtonLeft}
+	in.Update(input.State{Cursor: types.Position{X: 55, Y: 5}, Buttons: pressed})
+	d.Dispatch(root, in)
+
+	// Moving onto a while pressed still goes to b.
+	log = log[:0]
+	in.Update(input.State{Cursor: types.Position{X: 5, Y: 5}, Buttons: pressed})
+	d.Dispatch(root, in)
+	want := []string{"root:2:0", "b:2:1", "root:2:2"}
+	if log := without(log, PointerEnter, PointerLeave); fmt.Sprint(log) != fmt.Sprint(want) {
+		t.Errorf("PointerMove should be captured by b %v, but got %v", want, log)
+	}
+
+	log = log[:0]
+	in.Update(input.State{Cursor: types.Position{X: 5, Y: 5}})
+	d.Dispatch(root, in)
+	want = []string{"root:1:0", "b:1:1", "root:1:2"}
+	if log := without(log, PointerEnter, PointerLeave); fmt.Sprint(log) != fmt.Sprint(want) {
+		t.Errorf("PointerUp should be captured by b %v, but got %v", want, log)
+	}
+
+	if d.Focused() != b {
+		t.Errorf("Focused should return %v, but got %v", b, d.Focused())
+	}
+}
+
+func TestDispatchFocus(t *testing.T) {
+	log := []string{}
+	root, a, _, c := tree(&log)
+	d := NewDispatcher()
+	in := input.New()
+
+	in.Update(input.State{Cursor: types.Position{X: 65, Y: 15}, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}})
+	d.Dispatch(root, in)
+	if d.Focused() != c {
+		t.Fatalf("Focused should return %v, but got %v", c, d.Focused())
+	}
+
+	log = log[:0]
+	in.Update(input.State{Cursor: types.Position{X: 65, Y: 15}, Keys: []ebiten.Key{ebiten.KeyA}})
+	d.Dispatch(root, in)
+	want := []string{"root:1:0", "b:1:0", "c:1:1", "b:1:2", "root:1:2", "root:3:0", "b:3:0", "c:3:1", "b:3:2", "root:3:2"}
+	if log := without(log, PointerEnter, PointerLeave); fmt.Sprint(log) != fmt.Sprint(want) {
+		t.Errorf("KeyDown should go to the focused craft %v, but got %v", want, log)
+	}
+
+	log = log[:0]
+	in.Update(input.State{Cursor: types.Position{X: 5, Y: 5}, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}})
+	d.Dispatch(root, in)
+	want = []string{"root:2:0", "a:2:1", "root:2:2", "c:8:1", "a:7:1", "root:0:0", "a:0:1", "root:0:2", "root:4:0", "a:4:1", "root:4:2"}
+	if log := without(log, PointerEnter, PointerLeave); fmt.Sprint(log) != fmt.Sprint(want) {
+		t.Errorf("Dispatch should move the focus %v, but got %v", want, log)
+	}
+	if d.Focused() != a {
+		t.Errorf("Focused should return %v, but got %v", a, d.Focused())
+	}
+}
+
+func TestEventLocal(t *testing.T) {
+	log := []string{}
+	root, _, _, c := tree(&log)
+
+	e := &Event{Position: types.Position{X: 65, Y: 15}}
+	e.current = find(node{root, types.Position{}}, c)[2]
+
+	want := types.Position{X: 5, Y: 5}
+	if got := e.Local(); got != want {
+		t.Errorf("Local should return %v, but got %v", want, got)
+	}
+	if !e.Inside() {
+		t.Errorf("Inside should return true, but got false")
+	}
+}
+
+func TestDispatchHover(t *testing.T) {
+	log := []string{}
+	root, a, b, c := tree(&log)
+	d := NewDispatcher()
+	in := input.New()
+
+	tests := []struct {
+		position types.Position
+		want     []string
+		hovered  []Target
+	}{
+		{types.Position{X: 5, Y: 5}, []string{"root:9:1", "a:9:1"}, []Target{root, a}},
+		{types.Position{X: 65, Y: 15}, []string{"a:10:1", "b:9:1", "c:9:1"}, []Target{root, b, c}},
+		{types.Position{X: 55, Y: 5}, []string{"c:10:1"}, []Target{root, b}},
+		{types.Position{X: 200, Y: 5}, []string{"b:10:1", "root:10:1"}, []Target{}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			log = log[:0]
+			in.Update(input.State{Cursor: tt.position})
+			d.Dispatch(root, in)
+
+			got := without(log, PointerMove)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Dispatch should deliver %v, but got %v", tt.want, got)
+			}
+			if fmt.Sprint(d.Hovered()) != fmt.Sprint(tt.hovered) {
+				t.Errorf("Hovered should return %v, but got %v", tt.hovered, d.Hovered())
+			}
+		})
+	}
+}
+
+func TestDispatchTouches(t *testing.T) {
+	log := []string{}
+	root, _, _, _ := tree(&log)
+	d := NewDispatcher()
+	in := input.New()
+
+	tests := []struct {
+		touches []input.Touch
+		want    []string
+	}{
+		{[]input.Touch{{ID: 1, Position: types.Position{X: 5, Y: 5}}}, []string{"a:0:1"}},
+		{[]input.Touch{{ID: 1, Position: types.Position{X: 55, Y: 5}}, {ID: 2, Position: types.Position{X: 65, Y: 15}}}, []string{"a:2:1", "c:0:1"}},
+		{[]input.Touch{{ID: 2, Position: types.Position{X: 5, Y: 5}}}, []string{"c:2:1", "a:1:1"}},
+		{[]input.Touch{}, []string{"c:1:1"}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			log = log[:0]
+			in.Update(input.State{Touches: tt.touches})
+			d.Dispatch(root, in)
+
+			// Only the targets of the pointer events, since they bubble through root and b.
+			got := []string{}
+			for _, l := range without(log, PointerEnter, PointerLeave, Focus, Blur) {
+				if strings.HasSuffix(l, ":1") {
+					got = append(got, l)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Dispatch should deliver %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package event
+
+import (
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Drag is a drag and drop session.
+//
+// A craft starts it with Event.StartDrag while a button is held.
+// While dragging, PointerMove is also dispatched to the crafts under the pointer.
+// When the button is released, a Drop event is dispatched to the crafts under the pointer,
+// then the PointerUp to the craft the button was pressed on, and the session ends.
+type Drag struct {
+	Payload any
+	// Ghost is drawn following the cursor, at Offset from it.
+	Ghost    *ebiten.Image
+	Offset   types.Position
+	position types.Position
+	accepted bool
+}
+
+// Position is the position of the pointer dragging.
+func (d *Drag) Position() types.Position {
+	return d.position
+}
+
+// Accept marks the payload as dropped.
+func (d *Drag) Accept() {
+	d.accepted = true
+}
+
+// Accepted reports whether a craft accepted the drop.
+func (d *Drag) Accepted() bool {
+	return d.accepted
+}
+
+// StartDrag starts a drag session, replacing the current one.
+func (e *Event) StartDrag(d *Drag) {
+	if e.dispatcher != nil {
+		d.position = e.Position
+		e.dispatcher.drag = d
+	}
+}
+
+// Drag returns the current drag session, or nil.
+func (e *Event) Drag() *Drag {
+	if e.dispatcher == nil {
+		return nil
+	}
+	return e.dispatcher.drag
+}
+
+// Dragging returns the current drag session, or nil.
+func (d *Dispatcher) Dragging() *Drag {
+	return d.drag
+}
@@ -0,0 +1,182 @@
+package event
+
+import (
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Type of an event
+type Type int
+
+const (
+	PointerDown Type = iota
+	PointerUp
+	PointerMove
+	KeyDown
+	KeyUp
+	Wheel
+	Text
+	Focus
+	Blur
+	PointerEnter
+	PointerLeave
+	Drop
+	Activate
+	GamepadDown
+	GamepadUp
+	Back
+	// Compose carries in Chars the text an IME is composing at the focus,
+	// empty once it is committed as Text or canceled.
+	Compose
+)
+
+// Phase of the propagation
+//
+// An event travels from the root down to the target (Capture),
+// reaches the target (AtTarget), then goes back up to the root (Bubble).
+type Phase int
+
+const (
+	Capture Phase = iota
+	AtTarget
+	Bubble
+)
+
+// Modifier keys held during an event
+type Modifier int
+
+const (
+	Shift Modifier = 1 << iota
+	Control
+	Alt
+	Meta
+)
+
+// Event
+//
+// Touches are pointer events too: Touch is set, TouchID tells the fingers apart,
+// and Button is MouseButtonLeft so that crafts handling the left button work on touchscreens.
+type Event struct {
+	Type          Type
+	Phase         Phase
+	Position      types.Position
+	Button        ebiten.MouseButton
+	Touch         bool
+	TouchID       ebiten.TouchID
+	Key           ebiten.Key
+	Repeat        bool
+	GamepadButton ebiten.StandardGamepadButton
+	WheelX        float64
+	WheelY        float64
+	Chars         []rune
+	Modifiers     Modifier
+
+	target     Target
+	current    node
+	stopped    bool
+	dispatcher *Dispatcher
+}
+
+// Target is the deepest craft the event is dispatched to.
+func (e *Event) Target() Target {
+	return e.target
+}
+
+// Current is the craft the event is being delivered to.
+func (e *Event) Current() Target {
+	return e.current.target
+}
+
+// Local is Position relative to the current craft.
+func (e *Event) Local() types.Position {
+	return e.Position.Sub(e.current.position)
+}
+
+// Inside reports whether Position is inside the current craft.
+func (e *Event) Inside() bool {
+	p := e.Local()
+	s := e.current.target.Size()
+	return 0 <= p.X && p.X < s.X && 0 <= p.Y && p.Y < s.Y
+}
+
+// StopPropagation prevents the event from reaching further crafts.
+func (e *Event) StopPropagation() {
+	e.stopped = true
+}
+
+func (e *Event) Stopped() bool {
+	return e.stopped
+}
+
+// Has reports whether the modifier keys m are held.
+func (e *Event) Has(m Modifier) bool {
+	return e.Modifiers&m == m
+}
+
+// Typed reports whether the key of a KeyDown or KeyUp types a character,
+// so that crafts taking text can stop it before it is used as a shortcut.
+// Keys held with Control, Alt or Meta do not type.
+func (e *Event) Typed() bool {
+	if e.Type != KeyDown && e.Type != KeyUp {
+		return false
+	}
+	if e.Modifiers&(Control|Alt|Meta) != 0 {
+		return false
+	}
+	switch k := e.Key; {
+	case ebiten.KeyA <= k && k <= ebiten.KeyZ,
+		ebiten.KeyDigit0 <= k && k <= ebiten.KeyDigit9,
+		ebiten.KeyNumpad0 <= k && k <= ebiten.KeyNumpad9:
+		return true
+	}
+	switch e.Key {
+	case ebiten.KeySpace, ebiten.KeyBackquote, ebiten.KeyBackslash, ebiten.KeyBracketLeft, ebiten.KeyBracketRight,
+		ebiten.KeyComma, ebiten.KeyEqual, ebiten.KeyIntlBackslash, ebiten.KeyMinus, ebiten.KeyPeriod,
+		ebiten.KeyQuote, ebiten.KeySemicolon, ebiten.KeySlash,
+		ebiten.KeyNumpadAdd, ebiten.KeyNumpadDecimal, ebiten.KeyNumpadDivide, ebiten.KeyNumpadEqual,
+		ebiten.KeyNumpadMultiply, ebiten.KeyNumpadSubtract:
+		return true
+	}
+	return false
+}
+
+// Is reports whether the key, button or gamepad button of the event is bound to action in m.
+func (e *Event) Is(m *input.Map, action string) bool {
+	switch e.Type {
+	case KeyDown, KeyUp:
+		return m.Has(action, input.KeyBinding(e.Key))
+	case PointerDown, PointerUp:
+		return m.Has(action, input.MouseBinding(e.Button))
+	case GamepadDown, GamepadUp:
+		return m.Has(action, input.GamepadBinding(e.GamepadButton))
+	}
+	return false
+}
+
+// Target is a craft events can be dispatched to.
+type Target interface {
+	Size() types.Size
+}
+
+// Parent is a target containing other targets.
+type Parent interface {
+	Children() []Child
+}
+
+// Child is a target and its position relative to the parent.
+type Child struct {
+	Target   Target
+	Position types.Position
+}
+
+// Listener receives events.
+type Listener interface {
+	HandleEvent(*Event) error
+}
+
+// Cursor is a target that changes the cursor shape while the pointer is over it.
+// CursorShapeDefault leaves the shape to the parents.
+type Cursor interface {
+	CursorShape() ebiten.CursorShapeType
+}
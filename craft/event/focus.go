@@ -0,0 +1,266 @@
+package event
+
+import (
+	"errors"
+
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Focusable is a target that can be focused by pressing it or by keyboard navigation.
+// A target returning false, such as a disabled one, is skipped.
+type Focusable interface {
+	Focusable() bool
+}
+
+func isFocusable(t Target) bool {
+	f, ok := t.(Focusable)
+	return ok && f.Focusable()
+}
+
+// focusTarget is the deepest focusable target of p, or nil.
+func (p path) focusTarget() Target {
+	for i := len(p) - 1; i >= 0; i-- {
+		if isFocusable(p[i].target) {
+			return p[i].target
+		}
+	}
+	return nil
+}
+
+// pressFocus focuses the deepest focusable craft of a pressed path.
+// A press outside of a modal overlay leaves the focus in it.
+func (d *Dispatcher) pressFocus(hit path) error {
+	if hit == nil && d.modal() != nil {
+		return nil
+	}
+	return d.setFocus(hit.focusTarget())
+}
+
+// focusables returns the focusable nodes under n in tree order.
+func focusables(n node) []node {
+	var nodes []node
+	if isFocusable(n.target) {
+		nodes = append(nodes, n)
+	}
+	if parent, ok := n.target.(Parent); ok {
+		for _, c := range parent.Children() {
+			nodes = append(nodes, focusables(node{c.Target, n.position.Add(c.Position)})...)
+		}
+	}
+	return nodes
+}
+
+// center is the center of n in absolute coordinates.
+func (n node) center() types.Position {
+	size := n.target.Size()
+	return n.position.Add(types.Position{X: size.X / 2, Y: size.Y / 2})
+}
+
+// Navigation is a direction of spatial navigation.
+type Navigation int
+
+const (
+	NavigateUp Navigation = iota
+	NavigateDown
+	NavigateLeft
+	NavigateRight
+)
+
+// SetFocus focuses target, or clears the focus if target is nil.
+// target must be in the tree given to the last Dispatch or in an open overlay.
+func (d *Dispatcher) SetFocus(target Target) error {
+	d.visible = target != nil
+	return d.setFocus(target)
+}
+
+// FocusVisible reports whether the focus was moved by the keyboard or a gamepad,
+// and so should be shown with a focus ring.
+func (d *Dispatcher) FocusVisible() bool {
+	return d.visible && d.focus != nil
+}
+
+// FocusBounds returns the position and size of the focused craft.
+func (d *Dispatcher) FocusBounds() (types.Position, types.Size, bool) {
+	if d.focus == nil || d.root.target == nil {
+		return types.Position{}, types.Size{}, false
+	}
+	p := d.find(d.focus)
+	if p == nil {
+		return types.Position{}, types.Size{}, false
+	}
+	n := p[len(p)-1]
+	return n.position, n.target.Size(), true
+}
+
+// FocusNext moves the focus to the next focusable craft in tree order, wrapping around.
+func (d *Dispatcher) FocusNext() error {
+	return d.cycle(1)
+}
+
+// FocusPrevious moves the focus to the previous focusable craft in tree order, wrapping around.
+func (d *Dispatcher) FocusPrevious() error {
+	return d.cycle(-1)
+}
+
+func (d *Dispatcher) cycle(delta int) error {
+	nodes := d.focusables()
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	i := -1
+	for j, n := range nodes {
+		if n.target == d.focus {
+			i = j
+		}
+	}
+	switch {
+	case i < 0 && delta < 0:
+		i = len(nodes) - 1
+	case i < 0:
+		i = 0
+	default:
+		i = (i + delta + len(nodes)) % len(nodes)
+	}
+	return d.SetFocus(nodes[i].target)
+}
+
+// Navigate moves the focus to the nearest focusable craft in the direction.
+// Crafts straight ahead are preferred to those off to the side.
+// Without focus, the first focusable craft is focused.
+func (d *Dispatcher) Navigate(nav Navigation) error {
+	nodes := d.focusables()
+	var current *node
+	for i := range nodes {
+		if nodes[i].target == d.focus {
+			current = &nodes[i]
+		}
+	}
+	if current == nil {
+		if len(nodes) == 0 {
+			return nil
+		}
+		return d.SetFocus(nodes[0].target)
+	}
+
+	from := current.center()
+	var best Target
+	bestScore := 0
+	for _, n := range nodes {
+		if n.target == current.target {
+			continue
+		}
+		delta := n.center().Sub(from)
+		var ahead, side int
+		switch nav {
+		case NavigateUp:
+			ahead, side = -delta.Y, delta.X
+		case NavigateDown:
+			ahead, side = delta.Y, delta.X
+		case NavigateLeft:
+			ahead, side = -delta.X, delta.Y
+		case NavigateRight:
+			ahead, side = delta.X, delta.Y
+		}
+		if ahead <= 0 {
+			continue
+		}
+		score := ahead + 2*max(side, -side)
+		if best == nil || score < bestScore {
+			best, bestScore = n.target, score
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return d.SetFocus(best)
+}
+
+// Activate dispatches an Activate event to the focused craft.
+func (d *Dispatcher) Activate() error {
+	if d.focus == nil {
+		return nil
+	}
+	return d.dispatch(d.focusPath(d.root), &Event{Type: Activate})
+}
+
+// navigate handles a KeyDown or GamepadDown no craft stopped:
+// Tab and Shift+Tab cycle the focus, and the actions of the map move the focus,
+// activate the focused craft or go back; see SetActions.
+func (d *Dispatcher) navigate(e *Event) error {
+	if e.Type == KeyDown && e.Key == ebiten.KeyTab {
+		if e.Has(Shift) {
+			return d.FocusPrevious()
+		}
+		return d.FocusNext()
+	}
+
+	switch {
+	case e.Is(d.actions, input.ActionUp):
+		return d.Navigate(NavigateUp)
+	case e.Is(d.actions, input.ActionDown):
+		return d.Navigate(NavigateDown)
+	case e.Is(d.actions, input.ActionLeft):
+		return d.Navigate(NavigateLeft)
+	case e.Is(d.actions, input.ActionRight):
+		return d.Navigate(NavigateRight)
+	case e.Repeat:
+		return nil
+	case e.Is(d.actions, input.ActionConfirm):
+		return d.Activate()
+	case e.Is(d.actions, input.ActionBack):
+		return d.GoBack()
+	}
+	return nil
+}
+
+// dispatchGamepad dispatches the gamepad buttons to the focused craft.
+// Buttons no craft stopped navigate like the keyboard.
+func (d *Dispatcher) dispatchGamepad(r node, in *input.Input, newEvent func(Type) *Event) (err error) {
+	for _, b := range in.GamepadButtons() {
+		if !in.IsGamepadButtonRepeated(b) {
+			continue
+		}
+		e := newEvent(GamepadDown)
+		e.GamepadButton = b
+		e.Repeat = !in.IsGamepadButtonJustPressed(b)
+		err = errors.Join(err, d.dispatch(d.focusPath(r), e))
+		if !e.Stopped() {
+			err = errors.Join(err, d.navigate(e))
+		}
+	}
+
+	for _, b := range in.JustReleasedGamepadButtons() {
+		e := newEvent(GamepadUp)
+		e.GamepadButton = b
+		err = errors.Join(err, d.dispatch(d.focusPath(r), e))
+	}
+	return err
+}
+
+// GoBack dispatches a Back event to the focused craft, or the root.
+// If no craft stops it, the top overlay that is not passive is dismissed,
+// or without overlays, Back reports true until the next Dispatch so that the scene can go back.
+func (d *Dispatcher) GoBack() error {
+	e := &Event{Type: Back}
+	err := d.dispatch(d.focusPath(d.root), e)
+	if e.Stopped() {
+		return err
+	}
+	if o := d.top(); o != nil {
+		// The top overlay is closed instead, and a modal one without OnDismiss stays.
+		if !o.Modal || o.OnDismiss != nil {
+			err = errors.Join(err, d.dismiss(o))
+		}
+		return err
+	}
+	d.back = true
+	return err
+}
+
+// Back reports whether going back was requested and no craft handled it.
+func (d *Dispatcher) Back() bool {
+	return d.back
+}
@@ -0,0 +1,235 @@
+package event
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// grid builds 2x2 focusable targets of 10x10 in a disabled root:
+//
+// ```
+// 0 1
+// 2 3
+// ```
+func grid(log *[]string) (*testingTarget, []*testingTarget) {
+	cells := make([]*testingTarget, 4)
+	children := make([]Child, 4)
+	for i := range cells {
+		cells[i] = &testingTarget{name: fmt.Sprint(i), size: types.Size{X: 10, Y: 10}, log: log, stop: -1}
+		children[i] = Child{cells[i], types.Position{X: i % 2 * 10, Y: i / 2 * 10}}
+	}
+	root := &testingTarget{name: "root", size: types.Size{X: 20, Y: 20}, log: log, stop: -1, disabled: true, children: children}
+	return root, cells
+}
+
+// press dispatches a tick with keys pressed, then a tick with them released.
+func press(d *Dispatcher, root Target, in *input.Input, keys ...ebiten.Key) {
+	in.Update(input.State{Keys: keys})
+	d.Dispatch(root, in)
+	in.Update(input.State{})
+	d.Dispatch(root, in)
+}
+
+func TestFocusTab(t *testing.T) {
+	root, cells := grid(&[]string{})
+	cells[2].disabled = true
+
+	tests := []struct {
+		keys []ebiten.Key
+		want Target
+	}{
+		{[]ebiten.Key{ebiten.KeyTab}, cells[0]},
+		{[]ebiten.Key{ebiten.KeyTab}, cells[1]},
+		{[]ebiten.Key{ebiten.KeyTab}, cells[3]},
+		{[]ebiten.Key{ebiten.KeyTab}, cells[0]},
+		{[]ebiten.Key{ebiten.KeyShift, ebiten.KeyTab}, cells[3]},
+		{[]ebiten.Key{ebiten.KeyShift, ebiten.KeyTab}, cells[1]},
+	}
+
+	d := NewDispatcher()
+	in := input.New()
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			press(d, root, in, tt.keys...)
+			if d.Focused() != tt.want {
+				t.Errorf("Focused should return %v, but got %v", tt.want, d.Focused())
+			}
+			if !d.FocusVisible() {
+				t.Errorf("FocusVisible should return true, but got false")
+			}
+		})
+	}
+}
+
+func TestFocusNavigate(t *testing.T) {
+	root, cells := grid(&[]string{})
+
+	tests := []struct {
+		key  ebiten.Key
+		want Target
+	}{
+		{ebiten.KeyArrowDown, cells[0]},
+		{ebiten.KeyArrowDown, cells[2]},
+		{ebiten.KeyArrowDown, cells[2]},
+		{ebiten.KeyArrowRight, cells[3]},
+		{ebiten.KeyArrowUp, cells[1]},
+		{ebiten.KeyArrowLeft, cells[0]},
+	}
+
+	d := NewDispatcher()
+	in := input.New()
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			press(d, root, in, tt.key)
+			if d.Focused() != tt.want {
+				t.Errorf("Focused should return %v, but got %v", tt.want, d.Focused())
+			}
+		})
+	}
+}
+
+func TestFocusActivate(t *testing.T) {
+	log := []string{}
+	root, cells := grid(&log)
+	d := NewDispatcher()
+	in := input.New()
+
+	press(d, root, in, ebiten.KeyEnter)
+	if got := without(log, KeyDown, KeyUp, PointerEnter); len(got) != 0 {
+		t.Errorf("Activate should not be dispatched without focus, but got %v", got)
+	}
+
+	press(d, root, in, ebiten.KeyTab)
+	log = log[:0]
+	press(d, root, in, ebiten.KeySpace)
+	want := []string{"root:3:0", "0:3:1", "root:3:2", "root:12:0", "0:12:1", "root:12:2", "root:4:0", "0:4:1", "root:4:2"}
+	if fmt.Sprint(log) != fmt.Sprint(want) {
+		t.Errorf("Space should activate the focused craft %v, but got %v", want, log)
+	}
+
+	// A stopped key is not used for navigation.
+	cells[0].stop, cells[0].stopType = AtTarget, KeyDown
+	press(d, root, in, ebiten.KeyTab)
+	if d.Focused() != cells[0] {
+		t.Errorf("Focused should return %v, but got %v", cells[0], d.Focused())
+	}
+}
+
+func TestFocusPointer(t *testing.T) {
+	root, cells := grid(&[]string{})
+	d := NewDispatcher()
+	in := input.New()
+
+	press(d, root, in, ebiten.KeyTab)
+	in.Update(input.State{Cursor: types.Position{X: 15, Y: 15}, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}})
+	d.Dispatch(root, in)
+	if d.Focused() != cells[3] {
+		t.Errorf("Focused should return %v, but got %v", cells[3], d.Focused())
+	}
+	if d.FocusVisible() {
+		t.Errorf("FocusVisible should return false after a press, but got true")
+	}
+
+	p, size, _ := d.FocusBounds()
+	if p != (types.Position{X: 10, Y: 10}) || size != (types.Size{X: 10, Y: 10}) {
+		t.Errorf("FocusBounds should return (10, 10) 10x10, but got %v %v", p, size)
+	}
+
+	// The root is not focusable, so pressing it clears the focus.
+	cells[3].size = types.Size{X: 5, Y: 5}
+	in.Update(input.State{Cursor: types.Position{X: 18, Y: 18}})
+	d.Dispatch(root, in)
+	in.Update(input.State{Cursor: types.Position{X: 18, Y: 18}, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}})
+	d.Dispatch(root, in)
+	if d.Focused() != nil {
+		t.Errorf("Focused should return nil, but got %v", d.Focused())
+	}
+}
+
+func TestFocusGamepad(t *testing.T) {
+	log := []string{}
+	root, cells := grid(&log)
+	d := NewDispatcher()
+	in := input.New()
+
+	gamepad := func(buttons ...ebiten.StandardGamepadButton) {
+		in.Update(input.State{Gamepads: []input.Gamepad{{ID: 0, Buttons: buttons}}})
+		d.Dispatch(root, in)
+	}
+
+	tests := []struct {
+		button ebiten.StandardGamepadButton
+		ticks  int
+		want   Target
+	}{
+		{ebiten.StandardGamepadButtonLeftRight, 1, cells[0]},
+		{ebiten.StandardGamepadButtonLeftRight, 1, cells[1]},
+		{ebiten.StandardGamepadButtonLeftBottom, 1, cells[3]},
+		{ebiten.StandardGamepadButtonLeftLeft, 1, cells[2]},
+		// held: moves once, then repeats after the delay
+		{ebiten.StandardGamepadButtonLeftTop, 10, cells[0]},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			for range tt.ticks {
+				gamepad(tt.button)
+			}
+			gamepad()
+			if d.Focused() != tt.want {
+				t.Errorf("Focused should return %v, but got %v", tt.want, d.Focused())
+			}
+		})
+	}
+
+	log = log[:0]
+	gamepad(ebiten.StandardGamepadButtonRightBottom)
+	gamepad()
+	want := []string{"root:13:0", "0:13:1", "root:13:2", "root:12:0", "0:12:1", "root:12:2", "root:14:0", "0:14:1", "root:14:2"}
+	if fmt.Sprint(log) != fmt.Sprint(want) {
+		t.Errorf("A should activate the focused craft %v, but got %v", want, log)
+	}
+
+	gamepad(ebiten.StandardGamepadButtonRightRight)
+	if !d.Back() {
+		t.Errorf("Back should return true, but got false")
+	}
+	gamepad()
+	if d.Back() {
+		t.Errorf("Back should return false on the next tick, but got true")
+	}
+
+	cells[0].stop, cells[0].stopType = AtTarget, Back
+	gamepad(ebiten.StandardGamepadButtonRightRight)
+	if d.Back() {
+		t.Errorf("Back should return false when a craft stopped it, but got true")
+	}
+}
+
+func TestFocusActions(t *testing.T) {
+	root, cells := grid(&[]string{})
+	d := NewDispatcher().SetActions(input.DefaultMap().
+		Rebind(input.ActionRight, input.KeyBinding(ebiten.KeyD)).
+		Rebind(input.ActionBack, input.KeyBinding(ebiten.KeyQ)))
+	in := input.New()
+
+	press(d, root, in, ebiten.KeyTab)
+	press(d, root, in, ebiten.KeyArrowRight)
+	if d.Focused() != cells[0] {
+		t.Errorf("Focused should return %v, but got %v", cells[0], d.Focused())
+	}
+	press(d, root, in, ebiten.KeyD)
+	if d.Focused() != cells[1] {
+		t.Errorf("Focused should return %v, but got %v", cells[1], d.Focused())
+	}
+
+	in.Update(input.State{Keys: []ebiten.Key{ebiten.KeyQ}})
+	d.Dispatch(root, in)
+	if !d.Back() {
+		t.Errorf("Back should return true, but got false")
+	}
+}
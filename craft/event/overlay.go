@@ -0,0 +1,256 @@
+package event
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/a-skua/etk/craft/types"
+)
+
+// Overlay is a target shown above the tree at an absolute position, such as a popup.
+// Overlays are hit-tested before the tree, the last opened first,
+// and are not clipped by the crafts of the tree.
+//
+// A press outside a non-modal overlay dismisses it, and so does going back when no craft handles it.
+// A modal overlay keeps the pointer, the keys and the focus from the crafts below,
+// and is dismissed by going back only if it has OnDismiss.
+type Overlay struct {
+	Target   Target
+	Position types.Position
+	Modal    bool
+	// Owner is the craft that opened the overlay, such as the button of a popup.
+	// Pressing it does not dismiss the overlay, so that it can close the overlay itself.
+	Owner Target
+	// OnDismiss is called after the overlay is dismissed.
+	OnDismiss func() error
+	// Passive overlays, such as tooltips, are only drawn:
+	// they are never hit-tested nor focused, and presses and going back leave them open.
+	Passive bool
+	// Center keeps the overlay in the middle of the root, such as a dialog,
+	// even when it is opened before the first Dispatch or the root is resized.
+	Center bool
+
+	// restore is the craft focused when the overlay was opened.
+	restore Target
+	// parent is the overlay it was opened from, holding its owner or the focus, if any.
+	parent *Overlay
+	// placed is set once the overlay is fitted in a root.
+	placed bool
+}
+
+func (o *Overlay) node() node {
+	return node{o.Target, o.Position}
+}
+
+// Open shows o above the tree and the overlays already open,
+// moved to fit in the root, and focuses its first focusable craft.
+// Close gives the focus back.
+func (d *Dispatcher) Open(o *Overlay) error {
+	if slices.Contains(d.overlays, o) {
+		return nil
+	}
+	o.placed = false
+	d.fit(o)
+	o.restore = d.focus
+	from := o.Owner
+	if from == nil {
+		from = d.focus
+	}
+	o.parent = d.layer(from)
+	d.overlays = append(d.overlays, o)
+	if o.Passive {
+		return nil
+	}
+
+	if nodes := focusables(o.node()); len(nodes) > 0 {
+		return d.setFocus(nodes[0].target)
+	}
+	if o.Modal {
+		return d.setFocus(nil)
+	}
+	return nil
+}
+
+// fit moves o into the root, or to its middle if o is centered.
+// Without a root yet, o is fitted by the next Dispatch.
+func (d *Dispatcher) fit(o *Overlay) {
+	if d.root.target == nil {
+		return
+	}
+	root, size := d.root.target.Size(), o.Target.Size()
+	if o.Center {
+		o.Position = types.Position{X: (root.X - size.X) / 2, Y: (root.Y - size.Y) / 2}
+	}
+	o.Position.X = max(0, min(o.Position.X, root.X-size.X))
+	o.Position.Y = max(0, min(o.Position.Y, root.Y-size.Y))
+	o.placed = true
+}
+
+// RootSize returns the size of the root given to the last Dispatch,
+// which is the area overlays are fitted in.
+func (d *Dispatcher) RootSize() types.Size {
+	if d.root.target == nil {
+		return types.Size{}
+	}
+	return d.root.target.Size()
+}
+
+// Close hides o.
+// The overlays opened from it, such as a submenu or a dialog shown from a dialog,
+// are dismissed first, from the top, calling their OnDismiss. Other overlays stay.
+// The focus goes back to the craft focused when o was opened.
+func (d *Dispatcher) Close(o *Overlay) (err error) {
+	i := slices.Index(d.overlays, o)
+	if i < 0 {
+		return nil
+	}
+	for j := len(d.overlays) - 1; j > i; j-- {
+		if j < len(d.overlays) && d.overlays[j].openedFrom(o) {
+			err = errors.Join(err, d.dismiss(d.overlays[j]))
+		}
+	}
+	i = slices.Index(d.overlays, o)
+	if i < 0 {
+		return err
+	}
+	d.overlays = slices.Delete(slices.Clone(d.overlays), i, i+1)
+
+	if d.focus != nil && find(o.node(), d.focus) != nil {
+		restore := o.restore
+		if restore != nil && d.find(restore) == nil {
+			restore = nil
+		}
+		err = errors.Join(err, d.setFocus(restore))
+	}
+	return err
+}
+
+// openedFrom reports whether o was opened from parent, directly or through other overlays.
+func (o *Overlay) openedFrom(parent *Overlay) bool {
+	for p := o.parent; p != nil; p = p.parent {
+		if p == parent {
+			return true
+		}
+	}
+	return false
+}
+
+// layer returns the top overlay containing target, or nil if it is in the tree or nowhere.
+func (d *Dispatcher) layer(target Target) *Overlay {
+	if target == nil {
+		return nil
+	}
+	for i := len(d.overlays) - 1; i >= 0; i-- {
+		if find(d.overlays[i].node(), target) != nil {
+			return d.overlays[i]
+		}
+	}
+	return nil
+}
+
+// dismiss closes o and calls its OnDismiss.
+func (d *Dispatcher) dismiss(o *Overlay) error {
+	err := d.Close(o)
+	if o.OnDismiss != nil {
+		err = errors.Join(err, o.OnDismiss())
+	}
+	return err
+}
+
+// dismissOutside dismisses the non-modal overlays above the layer of hit, from the top.
+func (d *Dispatcher) dismissOutside(hit path) (err error) {
+	for i := len(d.overlays) - 1; i >= 0; i-- {
+		o := d.overlays[i]
+		if o.Passive {
+			continue
+		}
+		if o.Modal || (len(hit) > 0 && hit[0].target == o.Target) || (o.Owner != nil && hit.contains(o.Owner)) {
+			break
+		}
+		err = errors.Join(err, d.dismiss(o))
+	}
+	return err
+}
+
+// Overlays returns the open overlays, the top one last.
+func (d *Dispatcher) Overlays() []*Overlay {
+	return slices.Clone(d.overlays)
+}
+
+// top returns the top overlay that is not passive, or nil.
+func (d *Dispatcher) top() *Overlay {
+	for i := len(d.overlays) - 1; i >= 0; i-- {
+		if !d.overlays[i].Passive {
+			return d.overlays[i]
+		}
+	}
+	return nil
+}
+
+// modal returns the top modal overlay, or nil.
+func (d *Dispatcher) modal() *Overlay {
+	for i := len(d.overlays) - 1; i >= 0; i-- {
+		if d.overlays[i].Modal {
+			return d.overlays[i]
+		}
+	}
+	return nil
+}
+
+// layers returns the overlays from the top down to the top modal one,
+// followed by the root unless a modal overlay hides it.
+// Passive overlays are left out.
+func (d *Dispatcher) layers() []node {
+	var nodes []node
+	for i := len(d.overlays) - 1; i >= 0; i-- {
+		if d.overlays[i].Passive {
+			continue
+		}
+		nodes = append(nodes, d.overlays[i].node())
+		if d.overlays[i].Modal {
+			return nodes
+		}
+	}
+	return append(nodes, d.root)
+}
+
+// hitTest returns the path to the deepest target at p among the layers.
+func (d *Dispatcher) hitTest(p types.Position) path {
+	for _, n := range d.layers() {
+		if hit := hitTest(n, p); hit != nil {
+			return hit
+		}
+	}
+	return nil
+}
+
+// find returns the path to target in any layer, the overlays under a modal one included,
+// or nil.
+func (d *Dispatcher) find(target Target) path {
+	for i := len(d.overlays) - 1; i >= 0; i-- {
+		if p := find(d.overlays[i].node(), target); p != nil {
+			return p
+		}
+	}
+	if d.root.target == nil {
+		return nil
+	}
+	return find(d.root, target)
+}
+
+// focusables returns the focusable nodes of the layers in tree order,
+// the root first and the top overlay last.
+func (d *Dispatcher) focusables() []node {
+	var nodes []node
+	layers := d.layers()
+	for i := len(layers) - 1; i >= 0; i-- {
+		nodes = append(nodes, focusables(layers[i])...)
+	}
+	return nodes
+}
+
+// Dispatcher returns the dispatcher delivering the event, or nil,
+// so that crafts can open overlays and move the focus.
+func (e *Event) Dispatcher() *Dispatcher {
+	return e.dispatcher
+}
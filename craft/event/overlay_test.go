@@ -0,0 +1,182 @@
+package event
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// pressAt dispatches a tick with the left button pressed at p, then a tick with it released.
+func pressAt(d *Dispatcher, root Target, in *input.Input, p types.Position) {
+	in.Update(input.State{Cursor: p, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}})
+	d.Dispatch(root, in)
+	in.Update(input.State{Cursor: p})
+	d.Dispatch(root, in)
+}
+
+func TestOverlayHitTest(t *testing.T) {
+	log := []string{}
+	root, a, b, _ := tree(&log)
+	popup := &testingTarget{name: "popup", size: types.Size{X: 30, Y: 30}, log: &log, stop: -1}
+	d := NewDispatcher()
+	in := input.New()
+	d.Dispatch(root, in)
+
+	o := &Overlay{Target: popup, Position: types.Position{X: 40, Y: 10}}
+	d.Open(o)
+	if d.Focused() != popup {
+		t.Errorf("Open should focus %v, but got %v", popup, d.Focused())
+	}
+
+	tests := []struct {
+		position types.Position
+		want     Target
+	}{
+		{types.Position{X: 45, Y: 15}, popup},
+		{types.Position{X: 45, Y: 45}, a},
+		{types.Position{X: 75, Y: 45}, b},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			if got := d.hitTest(tt.position).last(); got != tt.want {
+				t.Errorf("hitTest should return %v, but got %v", tt.want, got)
+			}
+		})
+	}
+
+	// Open moves the overlay into the root.
+	d.Close(o)
+	o.Position = types.Position{X: 90, Y: -5}
+	d.Open(o)
+	if o.Position != (types.Position{X: 70, Y: 0}) {
+		t.Errorf("Open should move the overlay to (70, 0), but got %v", o.Position)
+	}
+}
+
+func TestOverlayDismiss(t *testing.T) {
+	log := []string{}
+	root, a, b, _ := tree(&log)
+	popup := &testingTarget{name: "popup", size: types.Size{X: 30, Y: 30}, log: &log, stop: -1}
+	d := NewDispatcher()
+	in := input.New()
+	d.Dispatch(root, in)
+
+	dismissed := 0
+	open := func(owner Target) *Overlay {
+		o := &Overlay{Target: popup, Position: types.Position{X: 60, Y: 60}, Owner: owner, OnDismiss: func() error { dismissed++; return nil }}
+		d.SetFocus(a)
+		d.Open(o)
+		return o
+	}
+
+	tests := []struct {
+		owner     Target
+		press     types.Position
+		open      bool
+		focus     Target
+		dismissed int
+	}{
+		{nil, types.Position{X: 70, Y: 70}, true, popup, 0},
+		{nil, types.Position{X: 10, Y: 10}, false, a, 1},
+		// the press on b reaches b, which takes the focus
+		{nil, types.Position{X: 55, Y: 5}, false, b, 1},
+		{b, types.Position{X: 55, Y: 5}, true, b, 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			dismissed = 0
+			o := open(tt.owner)
+			pressAt(d, root, in, tt.press)
+			if got := len(d.Overlays()) > 0; got != tt.open {
+				t.Errorf("the overlay should be open: %v, but got %v", tt.open, got)
+			}
+			if d.Focused() != tt.focus {
+				t.Errorf("Focused should return %v, but got %v", tt.focus, d.Focused())
+			}
+			if dismissed != tt.dismissed {
+				t.Errorf("OnDismiss should be called %d times, but got %d", tt.dismissed, dismissed)
+			}
+			d.Close(o)
+		})
+	}
+
+	o := open(nil)
+	press(d, root, in, ebiten.KeyEscape)
+	if len(d.Overlays()) != 0 || dismissed != 1 || d.Back() {
+		t.Errorf("Escape should dismiss the overlay instead of going back")
+	}
+	if d.Focused() != a {
+		t.Errorf("Focused should return %v, but got %v", a, d.Focused())
+	}
+	d.Close(o)
+}
+
+func TestOverlayModal(t *testing.T) {
+	log := []string{}
+	root, a, _, _ := tree(&log)
+	dialog := &testingTarget{name: "dialog", size: types.Size{X: 30, Y: 30}, log: &log, stop: -1}
+	d := NewDispatcher()
+	in := input.New()
+	d.Dispatch(root, in)
+	d.SetFocus(a)
+
+	o := &Overlay{Target: dialog, Position: types.Position{X: 60, Y: 60}, Modal: true}
+	d.Open(o)
+
+	log = log[:0]
+	pressAt(d, root, in, types.Position{X: 10, Y: 10})
+	if got := without(log, PointerEnter, PointerLeave); len(got) != 0 {
+		t.Errorf("crafts under a modal overlay should not receive events, but got %v", got)
+	}
+	press(d, root, in, ebiten.KeyTab)
+	if d.Focused() != dialog {
+		t.Errorf("Focused should return %v, but got %v", dialog, d.Focused())
+	}
+
+	// Going back keeps a modal overlay without OnDismiss.
+	press(d, root, in, ebiten.KeyEscape)
+	if len(d.Overlays()) != 1 || d.Back() {
+		t.Errorf("Escape should keep the modal overlay")
+	}
+
+	d.Close(o)
+	if d.Focused() != a {
+		t.Errorf("Close should give the focus back to %v, but got %v", a, d.Focused())
+	}
+}
+
+func TestOverlayPassive(t *testing.T) {
+	log := []string{}
+	root, a, _, _ := tree(&log)
+	popup := &testingTarget{name: "popup", size: types.Size{X: 30, Y: 30}, log: &log, stop: -1}
+	tip := &testingTarget{name: "tip", size: types.Size{X: 30, Y: 30}, log: &log, stop: -1}
+	d := NewDispatcher()
+	in := input.New()
+	d.Dispatch(root, in)
+
+	passive := &Overlay{Target: tip, Position: types.Position{X: 40, Y: 40}, Passive: true}
+	d.Open(passive)
+	if d.Focused() != nil {
+		t.Errorf("Open should not focus a passive overlay, but got %v", d.Focused())
+	}
+	dismissed := 0
+	o := &Overlay{Target: popup, Position: types.Position{X: 40, Y: 10}, OnDismiss: func() error { dismissed++; return nil }}
+	d.Open(o)
+	if got := d.hitTest(types.Position{X: 45, Y: 45}).last(); got != a {
+		t.Errorf("hitTest should return %v under the passive overlay, but got %v", a, got)
+	}
+
+	d.GoBack()
+	if dismissed != 1 || len(d.Overlays()) != 1 || d.Overlays()[0] != passive {
+		t.Errorf("GoBack should dismiss the overlay above the passive one, but got %v", d.Overlays())
+	}
+	pressAt(d, root, in, types.Position{X: 45, Y: 45})
+	if len(d.Overlays()) != 1 {
+		t.Errorf("a press should leave the passive overlay open, but got %v", d.Overlays())
+	}
+}
@@ -0,0 +1,69 @@
+package bidi
+
+import (
+	"golang.org/x/text/unicode/bidi"
+)
+
+// Run is a part of a line with a single direction.
+type Run struct {
+	Str string
+	RTL bool
+}
+
+// Runs splits a line into runs in visual order, from left to right.
+// The runes of a right-to-left run are kept in logical order.
+//
+// If rtl is true the paragraph is right-to-left, otherwise its direction is
+// detected from the first strong character.
+// Only two embedding levels are reordered, which covers left-to-right text
+// inside right-to-left text and vice versa.
+func Runs(str string, rtl bool) []Run {
+	if str == "" {
+		return nil
+	}
+
+	var opts []bidi.Option
+	if rtl {
+		opts = append(opts, bidi.DefaultDirection(bidi.RightToLeft))
+	}
+
+	p := bidi.Paragraph{}
+	if _, err := p.SetString(str, opts...); err != nil {
+		return []Run{{str, rtl}}
+	}
+	o, err := p.Order()
+	if err != nil {
+		return []Run{{str, rtl}}
+	}
+
+	runs := make([]Run, 0, o.NumRuns())
+	for i := 0; i < o.NumRuns(); i++ {
+		r := o.Run(i)
+		runs = append(runs, Run{r.String(), r.Direction() == bidi.RightToLeft})
+	}
+
+	if rtl {
+		for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+			runs[i], runs[j] = runs[j], runs[i]
+		}
+	}
+	return runs
+}
+
+// HasRTL reports whether str contains a right-to-left character.
+func HasRTL(str string) bool {
+	for _, r := range str {
+		p, _ := bidi.LookupRune(r)
+		switch p.Class() {
+		case bidi.R, bidi.AL:
+			return true
+		}
+	}
+	return false
+}
+
+// Reverse reverses the runes of a right-to-left run for a face that cannot shape it.
+// Brackets are mirrored.
+func Reverse(str string) string {
+	return bidi.ReverseString(str)
+}
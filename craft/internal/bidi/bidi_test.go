@@ -0,0 +1,56 @@
+package bidi
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRuns(t *testing.T) {
+	tests := []struct {
+		str  string
+		rtl  bool
+		want []Run
+	}{
+		{"", false, nil},
+		{"hello", false, []Run{{"hello", false}}},
+		{"abc אבג", false, []Run{{"abc ", false}, {"אבג", true}}},
+		{"אבג", true, []Run{{"אבג", true}}},
+		{"אבג abc", true, []Run{{"abc", false}, {"אבג ", true}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			runs := Runs(tt.str, tt.rtl)
+			if fmt.Sprint(runs) != fmt.Sprint(tt.want) {
+				t.Errorf("Runs should return %v, but got %v", tt.want, runs)
+			}
+		})
+	}
+}
+
+func TestHasRTL(t *testing.T) {
+	tests := []struct {
+		str  string
+		want bool
+	}{
+		{"hello", false},
+		{"こんにちは", false},
+		{"שלום", true},
+		{"مرحبا", true},
+		{"abc אבג", true},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			if got := HasRTL(tt.str); got != tt.want {
+				t.Errorf("HasRTL should return %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	if got := Reverse("אב(ג)"); got != "(ג)בא" {
+		t.Errorf("Reverse should return %q, but got %q", "(ג)בא", got)
+	}
+}
@@ -0,0 +1,299 @@
+package textedit
+
+import (
+	"unicode"
+)
+
+// Buffer is an editable rune buffer with a caret and a selection.
+//
+// The selection is the range between anchor and caret.
+// When anchor equals caret, nothing is selected.
+type Buffer struct {
+	text   []rune
+	caret  int
+	anchor int
+}
+
+func New(str string) *Buffer {
+	text := []rune(str)
+	return &Buffer{text, len(text), len(text)}
+}
+
+func (b *Buffer) String() string {
+	return string(b.text)
+}
+
+func (b *Buffer) Runes() []rune {
+	return b.text
+}
+
+func (b *Buffer) Len() int {
+	return len(b.text)
+}
+
+func (b *Buffer) Caret() int {
+	return b.caret
+}
+
+// Set replaces the whole text and moves the caret to the end.
+func (b *Buffer) Set(str string) {
+	b.text = []rune(str)
+	b.caret = len(b.text)
+	b.anchor = b.caret
+}
+
+func (b *Buffer) HasSelection() bool {
+	return b.caret != b.anchor
+}
+
+// Selection returns the selected range [start, end).
+func (b *Buffer) Selection() (start, end int) {
+	return min(b.caret, b.anchor), max(b.caret, b.anchor)
+}
+
+func (b *Buffer) Selected() string {
+	start, end := b.Selection()
+	return string(b.text[start:end])
+}
+
+func (b *Buffer) SelectAll() {
+	b.anchor = 0
+	b.caret = len(b.text)
+}
+
+// MoveTo moves the caret to i.
+// If extend is true, the selection is extended instead of collapsed.
+func (b *Buffer) MoveTo(i int, extend bool) {
+	b.caret = max(0, min(i, len(b.text)))
+	if !extend {
+		b.anchor = b.caret
+	}
+}
+
+func (b *Buffer) Left(extend bool) {
+	if b.HasSelection() && !extend {
+		start, _ := b.Selection()
+		b.MoveTo(start, false)
+		return
+	}
+	b.MoveTo(b.caret-1, extend)
+}
+
+func (b *Buffer) Right(extend bool) {
+	if b.HasSelection() && !extend {
+		_, end := b.Selection()
+		b.MoveTo(end, false)
+		return
+	}
+	b.MoveTo(b.caret+1, extend)
+}
+
+func (b *Buffer) WordLeft(extend bool) {
+	b.MoveTo(WordStart(b.text, b.caret), extend)
+}
+
+func (b *Buffer) WordRight(extend bool) {
+	b.MoveTo(WordEnd(b.text, b.caret), extend)
+}
+
+func (b *Buffer) Home(extend bool) {
+	b.MoveTo(0, extend)
+}
+
+func (b *Buffer) End(extend bool) {
+	b.MoveTo(len(b.text), extend)
+}
+
+// Insert replaces the selection with str.
+// If limit is positive, str is truncated so that the text does not exceed limit runes.
+// It reports whether the text was changed.
+func (b *Buffer) Insert(str string, limit int) bool {
+	runes := []rune(str)
+	start, end := b.Selection()
+	if limit > 0 {
+		room := limit - (len(b.text) - (end - start))
+		runes = runes[:max(0, min(len(runes), room))]
+	}
+	if len(runes) == 0 && start == end {
+		return false
+	}
+
+	text := make([]rune, 0, len(b.text)-(end-start)+len(runes))
+	text = append(text, b.text[:start]...)
+	text = append(text, runes...)
+	text = append(text, b.text[end:]...)
+	b.text = text
+	b.MoveTo(start+len(runes), false)
+	return true
+}
+
+// DeleteSelection removes the selected text and reports whether the text was changed.
+func (b *Buffer) DeleteSelection() bool {
+	if !b.HasSelection() {
+		return false
+	}
+	return b.Insert("", 0)
+}
+
+// Backspace removes the selection or the rune before the caret.
+func (b *Buffer) Backspace() bool {
+	if b.HasSelection() {
+		return b.DeleteSelection()
+	}
+	if b.caret == 0 {
+		return false
+	}
+	b.anchor = b.caret - 1
+	return b.DeleteSelection()
+}
+
+// Delete removes the selection or the rune after the caret.
+func (b *Buffer) Delete() bool {
+	if b.HasSelection() {
+		return b.DeleteSelection()
+	}
+	if b.caret == len(b.text) {
+		return false
+	}
+	b.anchor = b.caret + 1
+	return b.DeleteSelection()
+}
+
+// WordStart returns the start of the word before i.
+func WordStart(text []rune, i int) int {
+	for i > 0 && unicode.IsSpace(text[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(text[i-1]) {
+		i--
+	}
+	return i
+}
+
+// WordEnd returns the end of the word after i.
+func WordEnd(text []rune, i int) int {
+	for i < len(text) && unicode.IsSpace(text[i]) {
+		i++
+	}
+	for i < len(text) && !unicode.IsSpace(text[i]) {
+		i++
+	}
+	return i
+}
+
+// History records buffer states for undo and redo.
+type History struct {
+	undo  []state
+	redo  []state
+	limit int
+}
+
+type state struct {
+	text          []rune
+	caret, anchor int
+}
+
+func NewHistory(limit int) *History {
+	return &History{limit: limit}
+}
+
+// Save records the state of b before it is modified.
+func (h *History) Save(b *Buffer) {
+	h.undo = append(h.undo, state{b.text, b.caret, b.anchor})
+	if h.limit > 0 && len(h.undo) > h.limit {
+		h.undo = h.undo[len(h.undo)-h.limit:]
+	}
+	h.redo = h.redo[:0]
+}
+
+// Undo restores the previous state of b and reports whether there was one.
+func (h *History) Undo(b *Buffer) bool {
+	if len(h.undo) == 0 {
+		return false
+	}
+	h.redo = append(h.redo, state{b.text, b.caret, b.anchor})
+	b.restore(h.undo[len(h.undo)-1])
+	h.undo = h.undo[:len(h.undo)-1]
+	return true
+}
+
+// Redo restores the state undone last and reports whether there was one.
+func (h *History) Redo(b *Buffer) bool {
+	if len(h.redo) == 0 {
+		return false
+	}
+	h.undo = append(h.undo, state{b.text, b.caret, b.anchor})
+	b.restore(h.redo[len(h.redo)-1])
+	h.redo = h.redo[:len(h.redo)-1]
+	return true
+}
+
+// restore relies on Buffer never modifying text in place.
+func (b *Buffer) restore(s state) {
+	b.text, b.caret, b.anchor = s.text, s.caret, s.anchor
+}
+
+// Line is a visual line of text: the runes [Start, End) of logical line Number.
+// A line broken by '\n' ends before the newline.
+type Line struct {
+	Start, End int
+	Number     int
+}
+
+// Wrap splits text into visual lines no wider than width.
+// Lines are broken after spaces when possible, otherwise between runes.
+// If width is not positive, only '\n' breaks lines.
+// Each rune is measured once, and a line is as wide as the sum of its runes.
+func Wrap(text []rune, width int, measure func(string) int) []Line {
+	lines := []Line{}
+	number := 0
+	start := 0
+	widths := []int{}
+	for start <= len(text) {
+		end := start
+		for end < len(text) && text[end] != '\n' {
+			end++
+		}
+
+		if width > 0 {
+			widths = widths[:0]
+			x := 0
+			for i := start; i < end; i++ {
+				w := measure(string(text[i]))
+				widths = append(widths, w)
+				for x+w > width && i > start {
+					brk := i
+					for j := i; j > start; j-- {
+						if unicode.IsSpace(text[j-1]) {
+							brk = j
+							break
+						}
+					}
+					lines = append(lines, Line{start, brk, number})
+					widths = widths[brk-start:]
+					start = brk
+					x = 0
+					for _, w := range widths[:i-start] {
+						x += w
+					}
+				}
+				x += w
+			}
+		}
+
+		lines = append(lines, Line{start, end, number})
+		number++
+		start = end + 1
+	}
+	return lines
+}
+
+// LineAt returns the index of the visual line containing the rune index i.
+func LineAt(lines []Line, i int) int {
+	for n := len(lines) - 1; n > 0; n-- {
+		if lines[n].Start <= i {
+			return n
+		}
+	}
+	return 0
+}
@@ -0,0 +1,229 @@
+package textedit
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBufferInsert(t *testing.T) {
+	tests := []struct {
+		text         string
+		caret        int
+		anchor       int
+		insert       string
+		limit        int
+		want         string
+		wantCaret    int
+		wantModified bool
+	}{
+		{"", 0, 0, "abc", 0, "abc", 3, true},
+		{"ac", 1, 1, "b", 0, "abc", 2, true},
+		{"abcd", 1, 3, "x", 0, "axd", 2, true},
+		{"abc", 3, 3, "de", 4, "abcd", 4, true},
+		{"abcd", 4, 4, "e", 4, "abcd", 4, false},
+		{"abcd", 0, 4, "xyz", 2, "xy", 2, true},
+		{"あい", 1, 1, "う", 0, "あうい", 2, true},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			b := New(tt.text)
+			b.MoveTo(tt.anchor, false)
+			b.MoveTo(tt.caret, true)
+
+			modified := b.Insert(tt.insert, tt.limit)
+			if modified != tt.wantModified {
+				t.Errorf("Insert should return %v, but got %v", tt.wantModified, modified)
+			}
+			if b.String() != tt.want {
+				t.Errorf("String should return %q, but got %q", tt.want, b.String())
+			}
+			if b.Caret() != tt.wantCaret {
+				t.Errorf("Caret should return %d, but got %d", tt.wantCaret, b.Caret())
+			}
+			if b.HasSelection() {
+				t.Errorf("Insert should collapse the selection")
+			}
+		})
+	}
+}
+
+func TestBufferBackspace(t *testing.T) {
+	tests := []struct {
+		text      string
+		caret     int
+		anchor    int
+		want      string
+		wantCaret int
+	}{
+		{"abc", 3, 3, "ab", 2},
+		{"abc", 0, 0, "abc", 0},
+		{"abc", 1, 3, "a", 1},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			b := New(tt.text)
+			b.MoveTo(tt.anchor, false)
+			b.MoveTo(tt.caret, true)
+
+			b.Backspace()
+			if b.String() != tt.want {
+				t.Errorf("String should return %q, but got %q", tt.want, b.String())
+			}
+			if b.Caret() != tt.wantCaret {
+				t.Errorf("Caret should return %d, but got %d", tt.wantCaret, b.Caret())
+			}
+		})
+	}
+}
+
+func TestBufferDelete(t *testing.T) {
+	tests := []struct {
+		text      string
+		caret     int
+		anchor    int
+		want      string
+		wantCaret int
+	}{
+		{"abc", 0, 0, "bc", 0},
+		{"abc", 3, 3, "abc", 3},
+		{"abc", 2, 0, "c", 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			b := New(tt.text)
+			b.MoveTo(tt.anchor, false)
+			b.MoveTo(tt.caret, true)
+
+			b.Delete()
+			if b.String() != tt.want {
+				t.Errorf("String should return %q, but got %q", tt.want, b.String())
+			}
+			if b.Caret() != tt.wantCaret {
+				t.Errorf("Caret should return %d, but got %d", tt.wantCaret, b.Caret())
+			}
+		})
+	}
+}
+
+func TestBufferSelection(t *testing.T) {
+	b := New("hello world")
+
+	b.Home(false)
+	b.WordRight(true)
+	if b.Selected() != "hello" {
+		t.Errorf("Selected should return %q, but got %q", "hello", b.Selected())
+	}
+
+	b.Right(false)
+	if b.Caret() != 5 || b.HasSelection() {
+		t.Errorf("Right should collapse to the selection end, but got caret %d", b.Caret())
+	}
+
+	b.End(false)
+	b.WordLeft(true)
+	if b.Selected() != "world" {
+		t.Errorf("Selected should return %q, but got %q", "world", b.Selected())
+	}
+
+	b.Left(false)
+	if b.Caret() != 6 || b.HasSelection() {
+		t.Errorf("Left should collapse to the selection start, but got caret %d", b.Caret())
+	}
+
+	b.SelectAll()
+	if b.Selected() != "hello world" {
+		t.Errorf("Selected should return %q, but got %q", "hello world", b.Selected())
+	}
+}
+
+func TestHistory(t *testing.T) {
+	b := New("")
+	h := NewHistory(2)
+
+	for _, s := range []string{"a", "b", "c"} {
+		h.Save(b)
+		b.Insert(s, 0)
+	}
+
+	if !h.Undo(b) || b.String() != "ab" {
+		t.Errorf("Undo should restore %q, but got %q", "ab", b.String())
+	}
+	if !h.Undo(b) || b.String() != "a" {
+		t.Errorf("Undo should restore %q, but got %q", "a", b.String())
+	}
+	if h.Undo(b) {
+		t.Errorf("Undo should be limited, but got %q", b.String())
+	}
+
+	if !h.Redo(b) || b.String() != "ab" {
+		t.Errorf("Redo should restore %q, but got %q", "ab", b.String())
+	}
+
+	h.Save(b)
+	b.Insert("x", 0)
+	if h.Redo(b) {
+		t.Errorf("Save should clear redo, but got %q", b.String())
+	}
+}
+
+func TestWrap(t *testing.T) {
+	calls := 0
+	measure := func(s string) int { calls++; return len([]rune(s)) }
+
+	tests := []struct {
+		text  string
+		width int
+		want  []Line
+	}{
+		{"", 10, []Line{{0, 0, 0}}},
+		{"abc\ndef", 10, []Line{{0, 3, 0}, {4, 7, 1}}},
+		{"abc\n", 10, []Line{{0, 3, 0}, {4, 4, 1}}},
+		{"hello world", 8, []Line{{0, 6, 0}, {6, 11, 0}}},
+		{"abcdefghij", 4, []Line{{0, 4, 0}, {4, 8, 0}, {8, 10, 0}}},
+		{"ab cd\nef", 3, []Line{{0, 3, 0}, {3, 5, 0}, {6, 8, 1}}},
+		{"abcdefghij", 0, []Line{{0, 10, 0}}},
+		{"ab cdefg", 4, []Line{{0, 3, 0}, {3, 7, 0}, {7, 8, 0}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			calls = 0
+			lines := Wrap([]rune(tt.text), tt.width, measure)
+			if fmt.Sprint(lines) != fmt.Sprint(tt.want) {
+				t.Errorf("Wrap should return %v, but got %v", tt.want, lines)
+			}
+			if n := len([]rune(strings.ReplaceAll(tt.text, "\n", ""))); tt.width > 0 && calls != n {
+				t.Errorf("Wrap should measure each of the %d runes once, but measured %d times", n, calls)
+			}
+		})
+	}
+}
+
+func TestLineAt(t *testing.T) {
+	lines := []Line{{0, 6, 0}, {6, 11, 0}, {12, 15, 1}}
+
+	tests := []struct {
+		i    int
+		want int
+	}{
+		{0, 0},
+		{5, 0},
+		{6, 1},
+		{11, 1},
+		{12, 2},
+		{15, 2},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			n := LineAt(lines, tt.i)
+			if n != tt.want {
+				t.Errorf("LineAt should return %v, but got %v", tt.want, n)
+			}
+		})
+	}
+}
@@ -2,13 +2,27 @@ package util
 
 import (
 	"image/color"
+	"math"
 
+	"github.com/a-skua/etk/craft/internal/bidi"
 	"github.com/a-skua/etk/craft/types"
 	"github.com/hajimehoshi/bitmapfont/v3"
 	"github.com/hajimehoshi/ebiten/v2"
-	ebitenText "github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
+// Face is the font face of every text.
+//
+// Right-to-left runs are shaped only by a *text.GoTextFace,
+// other faces draw their runes in reverse order.
+var Face text.Face = text.NewGoXFace(bitmapfont.Face)
+
+// LineHeight is the height of a line of text.
+func LineHeight() int {
+	m := Face.Metrics()
+	return int(math.Ceil(m.HAscent + m.HDescent))
+}
+
 // CalcSize is types.Size + types.Margin
 func CalcSize(s types.Size, m types.Margin) types.Size {
 	return types.Size{
@@ -18,7 +32,110 @@ func CalcSize(s types.Size, m types.Margin) types.Size {
 }
 
 func DrawText(image *ebiten.Image, str string, color color.Color) {
-	ebitenText.Draw(image, str, bitmapfont.Face, 0, 12, color)
+	DrawTextAt(image, str, color, types.Position{})
+}
+
+// DrawTextAt draws a line of text whose top-left corner is p.
+func DrawTextAt(image *ebiten.Image, str string, color color.Color, p types.Position) {
+	drawLine(image, str, color, p, types.LeftToRight)
+}
+
+// DrawTexts draws texts from the start edge of direction.
+func DrawTexts(image *ebiten.Image, texts []types.TextInfo, d types.Direction) {
+	for _, t := range texts {
+		p := types.Position{}
+		if d == types.RightToLeft {
+			p.X = image.Bounds().Dx() - lineWidth(t.Str, d)
+		}
+		drawLine(image, t.Str, t.Color, p, d)
+	}
+}
+
+// drawLine draws the bidi runs of a line in visual order.
+func drawLine(image *ebiten.Image, str string, clr color.Color, p types.Position, d types.Direction) {
+	for _, r := range layoutLine(str, p, d) {
+		r.op.ColorScale.ScaleWithColor(clr)
+		text.Draw(image, r.str, r.face, r.op)
+	}
+}
+
+// textRun is a bidi run of a line with the face and the options to draw it.
+type textRun struct {
+	face text.Face
+	str  string
+	op   *text.DrawOptions
+}
+
+// layoutLine places the bidi runs of a line in visual order from p.
+func layoutLine(str string, p types.Position, d types.Direction) []textRun {
+	x := float64(p.X)
+	line := []textRun{}
+	for _, r := range runs(str, d) {
+		face, s := runFace(r)
+		op := &text.DrawOptions{}
+		// Only a right-to-left face needs its end aligned to start the run at x,
+		// other faces draw the reversed run from x already.
+		if f, ok := face.(*text.GoTextFace); ok && f.Direction == text.DirectionRightToLeft {
+			op.PrimaryAlign = text.AlignEnd
+		}
+		op.GeoM.Translate(x, float64(p.Y))
+		line = append(line, textRun{face, s, op})
+		x += text.Advance(s, face)
+	}
+	return line
+}
+
+func runs(str string, d types.Direction) []bidi.Run {
+	if d == types.LeftToRight && !bidi.HasRTL(str) {
+		return []bidi.Run{{Str: str}}
+	}
+	return bidi.Runs(str, d == types.RightToLeft)
+}
+
+// runFace returns the face and the string to draw a run with.
+func runFace(r bidi.Run) (text.Face, string) {
+	f, ok := Face.(*text.GoTextFace)
+	if !ok {
+		if r.RTL {
+			return Face, bidi.Reverse(r.Str)
+		}
+		return Face, r.Str
+	}
+
+	face := *f
+	face.Direction = text.DirectionLeftToRight
+	if r.RTL {
+		face.Direction = text.DirectionRightToLeft
+	}
+	return &face, r.Str
+}
+
+func lineWidth(str string, d types.Direction) int {
+	w := 0.0
+	for _, r := range runs(str, d) {
+		face, s := runFace(r)
+		w += text.Advance(s, face)
+	}
+	return int(math.Ceil(w))
+}
+
+// TextWidth is the advance width of a line of text.
+func TextWidth(str string) int {
+	return lineWidth(str, types.LeftToRight)
+}
+
+// TextIndex returns the rune index of the caret position nearest to x.
+func TextIndex(str string, x int) int {
+	runes := []rune(str)
+	left := 0
+	for i := range runes {
+		right := TextWidth(string(runes[:i+1]))
+		if x < (left+right)/2 {
+			return i
+		}
+		left = right
+	}
+	return len(runes)
 }
 
 func Sizein(s types.Size, p types.Position) bool {
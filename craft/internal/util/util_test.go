@@ -3,8 +3,10 @@ package util
 import (
 	"fmt"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
 func TestCalcSize(t *testing.T) {
@@ -49,3 +51,83 @@ func TestSizein(t *testing.T) {
 		})
 	}
 }
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		str  string
+		want int
+	}{
+		{"", 0},
+		{"abc", 18},
+		{"あいう", 36},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			w := TextWidth(tt.str)
+			if w != tt.want {
+				t.Errorf("TextWidth should return %v, but got %v", tt.want, w)
+			}
+		})
+	}
+}
+
+func TestTextIndex(t *testing.T) {
+	tests := []struct {
+		str  string
+		x    int
+		want int
+	}{
+		{"abc", -5, 0},
+		{"abc", 2, 0},
+		{"abc", 3, 1},
+		{"abc", 8, 1},
+		{"abc", 9, 2},
+		{"abc", 100, 3},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			idx := TextIndex(tt.str, tt.x)
+			if idx != tt.want {
+				t.Errorf("TextIndex should return %v, but got %v", tt.want, idx)
+			}
+		})
+	}
+}
+
+func TestLayoutLine(t *testing.T) {
+	tests := []struct {
+		str string
+		dir types.Direction
+	}{
+		{"שלום", types.LeftToRight},
+		{"שלום", types.RightToLeft},
+		{"abc שלום", types.LeftToRight},
+		{"abc שלום", types.RightToLeft},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			// Glyph images may be a pixel wider than their advance.
+			width := float64(TextWidth(tt.str) + 1)
+			last, drawn := -1.0, 0
+			for _, r := range layoutLine(tt.str, types.Position{}, tt.dir) {
+				for _, g := range text.AppendGlyphs(nil, r.str, r.face, &r.op.LayoutOptions) {
+					if g.Image == nil {
+						continue
+					}
+					x, _ := r.op.GeoM.Apply(g.X, g.Y)
+					if x <= last || x < 0 || x+float64(g.Image.Bounds().Dx()) > width {
+						t.Errorf("the glyphs of %q should be drawn side by side inside the image, but got one at %v", tt.str, x)
+					}
+					last = x
+					drawn++
+				}
+			}
+			if drawn != utf8.RuneCountInString(tt.str) {
+				t.Errorf("every glyph of %q should be drawn, but got %d", tt.str, drawn)
+			}
+		})
+	}
+}
@@ -0,0 +1,428 @@
+package craft
+
+import (
+	"errors"
+	"image/color"
+	"slices"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// ListSource provides the rows of a ListView.
+// Row is called only for the rows scrolled into view.
+type ListSource interface {
+	Len() int
+	Row(i int) Craft
+}
+
+// ListFunc is a ListSource made of functions.
+type ListFunc struct {
+	Count func() int
+	Build func(i int) Craft
+}
+
+func (f ListFunc) Len() int {
+	return f.Count()
+}
+
+func (f ListFunc) Row(i int) Craft {
+	return f.Build(i)
+}
+
+// Selection is how many rows of a ListView can be selected.
+type Selection int
+
+const (
+	SelectNone Selection = iota
+	SelectSingle
+	SelectMulti
+)
+
+type ListViewHandler func(*ListView) error
+
+type ListActivateHandler func(l *ListView, i int) error
+
+// ListView Craft
+//
+// ListView is a scrolling column of rows of the same height, built from a source
+// only when they are scrolled into view, so a list of thousands of rows costs as much as the visible ones.
+// Built rows are kept until they leave the view; Refresh rebuilds them after the data changed.
+//
+// A click selects a row, and with SelectMulti, Ctrl toggles a row and Shift selects a range.
+// While focused, the up and down actions move the current row, Home, End, Page Up and Page Down jump,
+// Space selects if the list has a selection mode, Ctrl+A selects every row, and activating calls the activate handler with the current row.
+// Moving past the first or the last row moves the focus out of the list.
+//
+// ```
+// +----------+
+// | row 3    |
+// |[row 4   ]|
+// | row 5    |
+// +----------+
+// ```
+type ListView struct {
+	size       types.Size
+	rowHeight  int
+	source     ListSource
+	rows       map[int]Craft
+	selection  Selection
+	selected   map[int]bool
+	anchor     int
+	current    int
+	offset     int
+	focused    bool
+	onSelect   ListViewHandler
+	onActivate ListActivateHandler
+	texts      []types.TextInfo
+	direction  types.Direction
+}
+
+var (
+	listSelection = color.RGBA{0x40, 0x60, 0xa0, 0x80}
+	listCurrent   = color.RGBA{0x80, 0x80, 0x80, 0xff}
+)
+
+func NewListView(size types.Size, rowHeight int, source ListSource) *ListView {
+	return &ListView{
+		size:      size,
+		rowHeight: max(rowHeight, 1),
+		source:    source,
+		rows:      map[int]Craft{},
+		selection: SelectSingle,
+		selected:  map[int]bool{},
+		texts:     []types.TextInfo{},
+	}
+}
+
+func (l *ListView) SetSelection(s Selection) *ListView {
+	l.selection = s
+	l.selected = map[int]bool{}
+	return l
+}
+
+func (l *ListView) OnSelect(handler ListViewHandler) *ListView {
+	l.onSelect = handler
+	return l
+}
+
+func (l *ListView) OnActivate(handler ListActivateHandler) *ListView {
+	l.onActivate = handler
+	return l
+}
+
+// Refresh drops the built rows, the selected rows past the end,
+// and keeps the current row in the list.
+func (l *ListView) Refresh() *ListView {
+	l.rows = map[int]Craft{}
+	n := l.source.Len()
+	for i := range l.selected {
+		if i >= n {
+			delete(l.selected, i)
+		}
+	}
+	l.current = max(0, min(l.current, n-1))
+	l.scroll(0)
+	return l
+}
+
+// Selected returns the indexes of the selected rows in order.
+func (l *ListView) Selected() []int {
+	indexes := make([]int, 0, len(l.selected))
+	for i := range l.selected {
+		indexes = append(indexes, i)
+	}
+	slices.Sort(indexes)
+	return indexes
+}
+
+func (l *ListView) IsSelected(i int) bool {
+	return l.selected[i]
+}
+
+// SetSelected selects the rows without calling the select handler.
+// A single selection keeps the last one.
+func (l *ListView) SetSelected(indexes ...int) *ListView {
+	l.selected = map[int]bool{}
+	for _, i := range indexes {
+		l.pick(i, false)
+	}
+	return l
+}
+
+// Current returns the row moved by the keys.
+func (l *ListView) Current() int {
+	return l.current
+}
+
+// ScrollTo makes the current row i and scrolls it into view.
+func (l *ListView) ScrollTo(i int) *ListView {
+	l.current = max(0, min(i, l.source.Len()-1))
+	l.reveal()
+	return l
+}
+
+// pick selects the row i, added to the selection if extend is set and multiple rows can be selected.
+func (l *ListView) pick(i int, extend bool) {
+	switch l.selection {
+	case SelectNone:
+		return
+	case SelectSingle:
+		extend = false
+	}
+	if !extend {
+		l.selected = map[int]bool{}
+	}
+	if 0 <= i && i < l.source.Len() {
+		l.selected[i] = true
+		l.anchor = i
+	}
+}
+
+// selectRange selects the rows from the anchor to i.
+func (l *ListView) selectRange(i int) {
+	l.selected = map[int]bool{}
+	for j := min(l.anchor, i); j <= max(l.anchor, i); j++ {
+		l.selected[j] = true
+	}
+}
+
+// changed calls the select handler if the selection is no longer old.
+func (l *ListView) changed(old []int) error {
+	if slices.Equal(old, l.Selected()) || l.onSelect == nil {
+		return nil
+	}
+	return l.onSelect(l)
+}
+
+// rowsShown is the number of rows fully in view.
+func (l *ListView) rowsShown() int {
+	return max(1, l.size.Y/l.rowHeight)
+}
+
+func (l *ListView) scroll(delta int) {
+	limit := max(0, l.source.Len()*l.rowHeight-l.size.Y)
+	l.offset = max(0, min(l.offset+delta, limit))
+}
+
+// reveal scrolls the current row into view.
+func (l *ListView) reveal() {
+	top := l.current * l.rowHeight
+	switch {
+	case top < l.offset:
+		l.scroll(top - l.offset)
+	case top+l.rowHeight > l.offset+l.size.Y:
+		l.scroll(top + l.rowHeight - l.size.Y - l.offset)
+	}
+}
+
+// visible returns the range of rows in view, and drops the built rows out of it.
+func (l *ListView) visible() (first, last int) {
+	first = l.offset / l.rowHeight
+	last = min(l.source.Len(), (l.offset+l.size.Y+l.rowHeight-1)/l.rowHeight)
+	for i := range l.rows {
+		if i < first || i >= last {
+			delete(l.rows, i)
+		}
+	}
+	return first, last
+}
+
+// row returns the row i, building it if needed.
+func (l *ListView) row(i int) Craft {
+	if c, ok := l.rows[i]; ok {
+		return c
+	}
+	c := l.source.Row(i)
+	SetDirection(c, l.direction)
+	l.rows[i] = c
+	return c
+}
+
+func (l *ListView) rowAt(y int) int {
+	return (y + l.offset) / l.rowHeight
+}
+
+func (l *ListView) Image() *ebiten.Image {
+	image := ebiten.NewImage(l.size.X, l.size.Y)
+
+	first, last := l.visible()
+	for i := first; i < last; i++ {
+		y := i*l.rowHeight - l.offset
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(0, float64(y))
+		image.DrawImage(l.row(i).Image(), op)
+		if l.selected[i] {
+			vector.DrawFilledRect(image, 0, float32(y), float32(l.size.X), float32(l.rowHeight), listSelection, false)
+		}
+		if l.focused && i == l.current {
+			vector.StrokeRect(image, 0.5, float32(y)+0.5, float32(l.size.X)-1, float32(l.rowHeight)-1, 1, listCurrent, false)
+		}
+	}
+	util.DrawTexts(image, l.texts, l.direction)
+
+	return image
+}
+
+func (l *ListView) Size() types.Size {
+	return l.size
+}
+
+func (l *ListView) AddText(str string, color color.Color) Self {
+	l.texts = append(l.texts, types.TextInfo{Str: str, Color: color})
+	return l
+}
+
+func (l *ListView) SetText(str string, color color.Color) Self {
+	l.texts = []types.TextInfo{{Str: str, Color: color}}
+	return l
+}
+
+func (l *ListView) ClearText() Self {
+	l.texts = []types.TextInfo{}
+	return l
+}
+
+func (l *ListView) SetDirection(d types.Direction) {
+	l.direction = d
+	for _, c := range l.rows {
+		SetDirection(c, d)
+	}
+}
+
+func (l *ListView) Const() *Image {
+	return &Image{l.Image(), []types.TextInfo{}, l.direction}
+}
+
+// Update updates the rows in view.
+func (l *ListView) Update(p types.Position) (err error) {
+	first, last := l.visible()
+	for i := first; i < last; i++ {
+		err = errors.Join(err, l.row(i).Update(p.Add(types.Position{Y: i*l.rowHeight - l.offset})))
+	}
+	return err
+}
+
+// Children are the rows in view.
+func (l *ListView) Children() []event.Child {
+	first, last := l.visible()
+	children := make([]event.Child, 0, last-first)
+	for i := first; i < last; i++ {
+		children = append(children, event.Child{Target: l.row(i), Position: types.Position{Y: i*l.rowHeight - l.offset}})
+	}
+	return children
+}
+
+func (l *ListView) Focusable() bool {
+	return true
+}
+
+func (l *ListView) HandleEvent(e *event.Event) error {
+	if e.Phase == event.Capture {
+		return nil
+	}
+	switch e.Type {
+	case event.Focus:
+		l.focused = true
+	case event.Blur:
+		l.focused = false
+	case event.Wheel:
+		l.scroll(-int(e.WheelY * float64(l.rowHeight)))
+		e.StopPropagation()
+	case event.PointerDown:
+		if e.Button != ebiten.MouseButtonLeft {
+			return nil
+		}
+		i := l.rowAt(e.Local().Y)
+		if i >= l.source.Len() {
+			return nil
+		}
+		old := l.Selected()
+		l.current = i
+		switch {
+		case l.selection == SelectMulti && e.Has(event.Shift):
+			l.selectRange(i)
+		case e.Has(event.Control) || e.Has(event.Meta):
+			if l.selected[i] && l.selection == SelectMulti {
+				delete(l.selected, i)
+			} else {
+				l.pick(i, true)
+			}
+		default:
+			l.pick(i, false)
+		}
+		return l.changed(old)
+	case event.Activate:
+		if l.source.Len() == 0 {
+			return nil
+		}
+		e.StopPropagation()
+		if l.onActivate != nil {
+			return l.onActivate(l, l.current)
+		}
+	case event.KeyDown, event.GamepadDown:
+		return l.handleKey(e)
+	}
+	return nil
+}
+
+func (l *ListView) handleKey(e *event.Event) error {
+	n := l.source.Len()
+	if n == 0 {
+		return nil
+	}
+	old := l.Selected()
+	target := l.current
+	actions := e.Dispatcher().Actions()
+	switch {
+	case e.Is(actions, input.ActionUp):
+		target--
+	case e.Is(actions, input.ActionDown):
+		target++
+	case e.Type != event.KeyDown:
+		return nil
+	case e.Key == ebiten.KeyHome:
+		target = 0
+	case e.Key == ebiten.KeyEnd:
+		target = n - 1
+	case e.Key == ebiten.KeyPageUp:
+		target = max(0, target-l.rowsShown())
+	case e.Key == ebiten.KeyPageDown:
+		target = min(n-1, target+l.rowsShown())
+	case e.Key == ebiten.KeySpace && !e.Repeat && l.selection != SelectNone:
+		// Without selection, Space is left to the dispatcher to activate the row.
+		e.StopPropagation()
+		if l.selection == SelectMulti && l.selected[l.current] {
+			delete(l.selected, l.current)
+		} else {
+			l.pick(l.current, true)
+		}
+		return l.changed(old)
+	case e.Key == ebiten.KeyA && e.Has(event.Control) && l.selection == SelectMulti:
+		e.StopPropagation()
+		for i := range n {
+			l.selected[i] = true
+		}
+		return l.changed(old)
+	default:
+		return nil
+	}
+	if target < 0 || target >= n {
+		// Past the ends, the focus moves on.
+		return nil
+	}
+
+	e.StopPropagation()
+	l.current = target
+	l.reveal()
+	if e.Has(event.Shift) && l.selection == SelectMulti {
+		l.selectRange(target)
+	} else if l.selection == SelectSingle {
+		l.pick(target, false)
+	}
+	return l.changed(old)
+}
@@ -0,0 +1,196 @@
+package craft
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// rows is a source of n rows of 100x10 counting the rows built.
+func rows(n int, built *[]int) ListSource {
+	return ListFunc{
+		Count: func() int { return n },
+		Build: func(i int) Craft {
+			*built = append(*built, i)
+			return NewFill(types.Size{X: 100, Y: 10}, color.White)
+		},
+	}
+}
+
+var _ Craft = NewListView(types.Size{X: 100, Y: 30}, 10, rows(0, &[]int{}))
+
+func TestListViewVirtual(t *testing.T) {
+	built := []int{}
+	l := NewListView(types.Size{X: 100, Y: 35}, 10, rows(1000, &built))
+
+	tests := []struct {
+		wheel float64
+		want  []int
+	}{
+		{0, []int{0, 1, 2, 3}},
+		// scrolled by 2 rows: 2 and 3 are kept
+		{-2, []int{4, 5}},
+		{100, []int{0, 1}},
+		{-1000, []int{996, 997, 998, 999}},
+	}
+
+	in := input.New()
+	d := event.NewDispatcher()
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			built = built[:0]
+			in.Update(input.State{Cursor: types.Position{X: 5, Y: 5}, WheelY: tt.wheel})
+			d.Dispatch(l, in)
+			l.Image()
+			if fmt.Sprint(built) != fmt.Sprint(tt.want) {
+				t.Errorf("rows %v should be built, but got %v", tt.want, built)
+			}
+		})
+	}
+}
+
+// failing is a row whose Update fails.
+type failing struct {
+	*Fill
+	err error
+}
+
+func (f failing) Update(types.Position) error {
+	return f.err
+}
+
+func TestListViewUpdate(t *testing.T) {
+	errs := []error{errors.New("first"), errors.New("second"), errors.New("third")}
+	l := NewListView(types.Size{X: 100, Y: 20}, 10, ListFunc{
+		Count: func() int { return len(errs) },
+		Build: func(i int) Craft { return failing{NewFill(types.Size{X: 100, Y: 10}, color.White), errs[i]} },
+	})
+
+	err := l.Update(types.Position{})
+	if !errors.Is(err, errs[0]) || !errors.Is(err, errs[1]) || errors.Is(err, errs[2]) {
+		t.Errorf("Update should return the errors of the rows in view, but got %v", err)
+	}
+}
+
+func TestListViewPointer(t *testing.T) {
+	type click struct {
+		y    int
+		keys []ebiten.Key
+	}
+	ctrl, shift := []ebiten.Key{ebiten.KeyControl}, []ebiten.Key{ebiten.KeyShift}
+	tests := []struct {
+		selection Selection
+		clicks    []click
+		want      []int
+		calls     int
+	}{
+		{SelectSingle, []click{{5, nil}, {25, nil}}, []int{2}, 2},
+		{SelectSingle, []click{{5, nil}, {25, ctrl}}, []int{2}, 2},
+		{SelectMulti, []click{{5, nil}, {25, ctrl}, {15, ctrl}}, []int{0, 1, 2}, 3},
+		{SelectMulti, []click{{5, nil}, {25, ctrl}, {5, ctrl}}, []int{2}, 3},
+		{SelectMulti, []click{{5, nil}, {35, shift}}, []int{0, 1, 2, 3}, 2},
+		{SelectNone, []click{{5, nil}}, []int{}, 0},
+		// past the last row
+		{SelectSingle, []click{{55, nil}}, []int{}, 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			built := []int{}
+			calls := 0
+			l := NewListView(types.Size{X: 100, Y: 60}, 10, rows(5, &built)).
+				SetSelection(tt.selection).
+				OnSelect(func(*ListView) error { calls++; return nil })
+
+			in := input.New()
+			d := event.NewDispatcher()
+			for _, c := range tt.clicks {
+				p := types.Position{X: 5, Y: c.y}
+				in.Update(input.State{Cursor: p, Keys: c.keys, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}})
+				d.Dispatch(l, in)
+				in.Update(input.State{Cursor: p})
+				d.Dispatch(l, in)
+			}
+
+			if got := l.Selected(); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Selected should return %v, but got %v", tt.want, got)
+			}
+			if calls != tt.calls {
+				t.Errorf("handler should be called %d times, but got %d", tt.calls, calls)
+			}
+		})
+	}
+}
+
+func TestListViewKeys(t *testing.T) {
+	built := []int{}
+	activated := []int{}
+	l := NewListView(types.Size{X: 100, Y: 30}, 10, rows(100, &built)).
+		OnActivate(func(l *ListView, i int) error { activated = append(activated, i); return nil })
+	in := input.New()
+	d := event.NewDispatcher()
+	d.SetFocus(l)
+
+	tests := []struct {
+		key      ebiten.Key
+		current  int
+		offset   int
+		selected []int
+	}{
+		{ebiten.KeyArrowDown, 1, 0, []int{1}},
+		{ebiten.KeyArrowDown, 2, 0, []int{2}},
+		{ebiten.KeyArrowDown, 3, 10, []int{3}},
+		{ebiten.KeyPageDown, 6, 40, []int{6}},
+		{ebiten.KeyEnd, 99, 970, []int{99}},
+		{ebiten.KeyArrowDown, 99, 970, []int{99}},
+		{ebiten.KeyHome, 0, 0, []int{0}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			in.Update(input.State{Keys: []ebiten.Key{tt.key}})
+			d.Dispatch(l, in)
+			in.Update(input.State{})
+			d.Dispatch(l, in)
+			if l.Current() != tt.current || l.offset != tt.offset {
+				t.Errorf("Current and offset should be %d and %d, but got %d and %d", tt.current, tt.offset, l.Current(), l.offset)
+			}
+			if got := l.Selected(); fmt.Sprint(got) != fmt.Sprint(tt.selected) {
+				t.Errorf("Selected should return %v, but got %v", tt.selected, got)
+			}
+		})
+	}
+
+	d.Activate()
+	if fmt.Sprint(activated) != "[0]" {
+		t.Errorf("activate handler should be called with [0], but got %v", activated)
+	}
+
+	// the rows follow the action map
+	d.Actions().Bind(input.ActionDown, input.KeyBinding(ebiten.KeyJ))
+	in.Update(input.State{Keys: []ebiten.Key{ebiten.KeyJ}})
+	d.Dispatch(l, in)
+	in.Update(input.State{})
+	d.Dispatch(l, in)
+	if l.Current() != 1 {
+		t.Errorf("a key bound to down should move the current row to 1, but got %d", l.Current())
+	}
+	in.Update(input.State{Keys: []ebiten.Key{ebiten.KeyArrowUp}})
+	d.Dispatch(l, in)
+	in.Update(input.State{})
+	d.Dispatch(l, in)
+
+	// without selection, Space activates the current row
+	l.SetSelection(SelectNone)
+	in.Update(input.State{Keys: []ebiten.Key{ebiten.KeySpace}})
+	d.Dispatch(l, in)
+	if fmt.Sprint(activated) != "[0 0]" || len(l.Selected()) != 0 {
+		t.Errorf("Space should activate the row without selecting it, but got %v and %v", activated, l.Selected())
+	}
+}
@@ -0,0 +1,343 @@
+package locale
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Form is a CLDR plural category.
+type Form string
+
+const (
+	Zero  Form = "zero"
+	One   Form = "one"
+	Two   Form = "two"
+	Few   Form = "few"
+	Many  Form = "many"
+	Other Form = "other"
+)
+
+var forms = []Form{Zero, One, Two, Few, Many, Other}
+
+// Message is a localized message for each plural form.
+// A message without plurals only has Other.
+type Message map[Form]string
+
+// Catalog is the messages of a locale by key.
+type Catalog map[string]Message
+
+// PluralRule selects the plural form of n.
+type PluralRule func(n int) Form
+
+var pluralRules = map[string]PluralRule{
+	"en": func(n int) Form {
+		if n == 1 {
+			return One
+		}
+		return Other
+	},
+	"ja": func(n int) Form {
+		return Other
+	},
+}
+
+// RegisterPluralRule sets the plural rule of a language such as "fr".
+// Languages without a rule use the English one.
+func RegisterPluralRule(lang string, rule PluralRule) {
+	pluralRules[lang] = rule
+}
+
+func pluralRule(locale string) PluralRule {
+	if rule, ok := pluralRules[language(locale)]; ok {
+		return rule
+	}
+	return pluralRules["en"]
+}
+
+// Args are the values of placeholders such as "{name}".
+// The value of "count" also selects the plural form.
+type Args map[string]any
+
+// Format of a catalog file
+type Format int
+
+const (
+	JSON Format = iota
+	TOML
+)
+
+// Bundle holds the catalogs of every locale and the current locale.
+type Bundle struct {
+	catalogs map[string]Catalog
+	locale   string
+	fallback []string
+	version  int
+}
+
+// New creates a bundle using locale, falling back to the fallback locales in order.
+func New(locale string, fallback ...string) *Bundle {
+	return &Bundle{
+		catalogs: map[string]Catalog{},
+		locale:   locale,
+		fallback: fallback,
+	}
+}
+
+func (b *Bundle) Locale() string {
+	return b.locale
+}
+
+// SetLocale switches the locale.
+// Crafts wrapped by Text pick up the new locale on their next Update.
+func (b *Bundle) SetLocale(locale string) {
+	if b.locale == locale {
+		return
+	}
+	b.locale = locale
+	b.version++
+}
+
+// SetFallback replaces the fallback locales.
+func (b *Bundle) SetFallback(fallback ...string) {
+	b.fallback = fallback
+	b.version++
+}
+
+// Version changes whenever the messages returned by T may change.
+func (b *Bundle) Version() int {
+	return b.version
+}
+
+// Add merges the catalog into the messages of locale.
+func (b *Bundle) Add(locale string, catalog Catalog) {
+	if b.catalogs[locale] == nil {
+		b.catalogs[locale] = Catalog{}
+	}
+	for key, msg := range catalog {
+		b.catalogs[locale][key] = msg
+	}
+	b.version++
+}
+
+// Parse adds the messages of a JSON or TOML document to locale.
+//
+// A string value is a message, a table of other and at least one more plural form is a plural message,
+// and other tables are flattened into dotted keys:
+//
+//	title = "Title"
+//	[menu]
+//	start = "Start"
+//	[apples]
+//	one = "{count} apple"
+//	other = "{count} apples"
+//
+// Languages with one plural form, such as Japanese, write plural messages as strings.
+func (b *Bundle) Parse(locale string, data []byte, format Format) error {
+	var m map[string]any
+	switch format {
+	case JSON:
+		if err := json.Unmarshal(data, &m); err != nil {
+			return fmt.Errorf("locale: %w", err)
+		}
+	case TOML:
+		if err := toml.Unmarshal(data, &m); err != nil {
+			return fmt.Errorf("locale: %w", err)
+		}
+	default:
+		return fmt.Errorf("locale: unknown format %d", format)
+	}
+
+	catalog := Catalog{}
+	if err := flatten(catalog, "", m); err != nil {
+		return err
+	}
+	b.Add(locale, catalog)
+	return nil
+}
+
+// LoadFile adds a catalog file such as "ja.toml" or "en-US.json".
+// The locale is the file name without its extension.
+func (b *Bundle) LoadFile(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("locale: %w", err)
+	}
+	return b.parseFile(filepath.Base(name), data)
+}
+
+// LoadFS adds every catalog file in fsys matching pattern, such as "locales/*.json".
+func (b *Bundle) LoadFS(fsys fs.FS, pattern string) error {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return fmt.Errorf("locale: %w", err)
+	}
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("locale: %w", err)
+		}
+		if err := b.parseFile(path.Base(name), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Bundle) parseFile(name string, data []byte) error {
+	ext := path.Ext(name)
+	locale := strings.TrimSuffix(name, ext)
+	switch strings.ToLower(ext) {
+	case ".json":
+		return b.Parse(locale, data, JSON)
+	case ".toml":
+		return b.Parse(locale, data, TOML)
+	}
+	return fmt.Errorf("locale: unknown format %q", name)
+}
+
+// T returns the message of key in the current locale with args applied.
+// If no locale in the fallback chain has the key, the key itself is returned.
+func (b *Bundle) T(key string, args Args) string {
+	for _, locale := range b.chain() {
+		msg, ok := b.catalogs[locale][key]
+		if !ok {
+			continue
+		}
+
+		form := Other
+		if n, ok := count(args); ok {
+			form = pluralRule(locale)(n)
+		}
+		str, ok := msg[form]
+		if !ok {
+			str = msg[Other]
+		}
+		return format(str, args)
+	}
+	return key
+}
+
+// count returns the count in args, such as a number decoded from JSON.
+// A count with a fraction selects no form, so the message of Other is used.
+func count(args Args) (int, bool) {
+	switch n := args["count"].(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case uint:
+		return int(n), true
+	case float64:
+		return int(n), n == math.Trunc(n)
+	}
+	return 0, false
+}
+
+// chain is the current locale, its language, then the fallback locales and their languages.
+func (b *Bundle) chain() []string {
+	chain := []string{}
+	seen := map[string]bool{}
+	for _, locale := range append([]string{b.locale}, b.fallback...) {
+		for _, l := range []string{locale, language(locale)} {
+			if !seen[l] {
+				seen[l] = true
+				chain = append(chain, l)
+			}
+		}
+	}
+	return chain
+}
+
+// language is the language subtag of locale: "ja" of "ja-JP".
+func language(locale string) string {
+	lang, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	return lang
+}
+
+// format replaces "{name}" with args["name"]. Unknown placeholders are kept.
+func format(str string, args Args) string {
+	if len(args) == 0 {
+		return str
+	}
+
+	var sb strings.Builder
+	for {
+		start := strings.IndexByte(str, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(str[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		sb.WriteString(str[:start])
+		if v, ok := args[str[start+1:end]]; ok {
+			fmt.Fprint(&sb, v)
+		} else {
+			sb.WriteString(str[start : end+1])
+		}
+		str = str[end+1:]
+	}
+	sb.WriteString(str)
+	return sb.String()
+}
+
+func flatten(catalog Catalog, prefix string, m map[string]any) error {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		switch v := v.(type) {
+		case string:
+			catalog[key] = Message{Other: v}
+		case map[string]any:
+			if !isPlural(v) {
+				if err := flatten(catalog, key, v); err != nil {
+					return err
+				}
+				continue
+			}
+			msg := Message{}
+			for form, str := range v {
+				msg[Form(form)] = str.(string)
+			}
+			catalog[key] = msg
+		default:
+			return fmt.Errorf("locale: invalid message %q: %v", key, v)
+		}
+	}
+	return nil
+}
+
+// isPlural reports whether m is a table of plural forms including Other and another form.
+// A table of Other alone is a table named "other".
+func isPlural(m map[string]any) bool {
+	if _, ok := m[string(Other)]; !ok || len(m) < 2 {
+		return false
+	}
+	for k, v := range m {
+		if _, ok := v.(string); !ok {
+			return false
+		}
+		found := false
+		for _, f := range forms {
+			found = found || string(f) == k
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package locale
+
+import (
+	"fmt"
+	"testing"
+	"testing/fstest"
+)
+
+func newTestBundle(t *testing.T) *Bundle {
+	b := New("ja-JP", "en")
+
+	err := b.Parse("en", []byte(`{
+		"title": "Title",
+		"greeting": "Hello, {name}!",
+		"menu": {"start": "Start", "quit": "Quit"},
+		"apples": {"one": "{count} apple", "other": "{count} apples"},
+		"units": {"other": "Others"}
+	}`), JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = b.Parse("ja", []byte(`
+title = "タイトル"
+greeting = "こんにちは、{name}！"
+apples = "りんご{count}個"
+
+[menu]
+start = "スタート"
+`), TOML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func TestBundleT(t *testing.T) {
+	b := newTestBundle(t)
+
+	tests := []struct {
+		locale string
+		key    string
+		args   Args
+		want   string
+	}{
+		{"ja-JP", "title", nil, "タイトル"},
+		{"ja-JP", "greeting", Args{"name": "etk"}, "こんにちは、etk！"},
+		{"ja-JP", "menu.start", nil, "スタート"},
+		{"ja-JP", "menu.quit", nil, "Quit"},
+		{"ja-JP", "apples", Args{"count": 1}, "りんご1個"},
+		{"ja-JP", "missing", nil, "missing"},
+		{"en", "title", nil, "Title"},
+		{"en", "apples", Args{"count": 1}, "1 apple"},
+		{"en", "apples", Args{"count": 3}, "3 apples"},
+		{"en", "apples", Args{"count": 1.0}, "1 apple"},
+		{"en", "apples", Args{"count": 1.5}, "1.5 apples"},
+		{"en", "units.other", nil, "Others"},
+		{"en", "greeting", Args{}, "Hello, {name}!"},
+		{"fr", "title", nil, "Title"},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			b.SetLocale(tt.locale)
+			if got := b.T(tt.key, tt.args); got != tt.want {
+				t.Errorf("T should return %q, but got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBundleVersion(t *testing.T) {
+	b := New("en")
+	v := b.Version()
+
+	b.SetLocale("en")
+	if b.Version() != v {
+		t.Errorf("SetLocale should not change the version for the same locale")
+	}
+
+	b.SetLocale("ja")
+	if b.Version() == v {
+		t.Errorf("SetLocale should change the version")
+	}
+}
+
+func TestBundleLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{"title": "Title"}`)},
+		"locales/ja.toml": {Data: []byte(`title = "タイトル"`)},
+	}
+
+	b := New("ja", "en")
+	if err := b.LoadFS(fsys, "locales/*"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := b.T("title", nil); got != "タイトル" {
+		t.Errorf("T should return %q, but got %q", "タイトル", got)
+	}
+	b.SetLocale("en")
+	if got := b.T("title", nil); got != "Title" {
+		t.Errorf("T should return %q, but got %q", "Title", got)
+	}
+}
+
+func TestBundleParseError(t *testing.T) {
+	tests := []struct {
+		data   string
+		format Format
+	}{
+		{`{"title": 1}`, JSON},
+		{`{`, JSON},
+		{`title = `, TOML},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			if err := New("en").Parse("en", []byte(tt.data), tt.format); err == nil {
+				t.Errorf("Parse should return an error")
+			}
+		})
+	}
+}
+
+func TestBundleChain(t *testing.T) {
+	b := New("ja-JP", "en-US", "ja")
+
+	want := []string{"ja-JP", "ja", "en-US", "en"}
+	if got := b.chain(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("chain should return %v, but got %v", want, got)
+	}
+}
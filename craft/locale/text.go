@@ -0,0 +1,84 @@
+package locale
+
+import (
+	"image/color"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Text
+//
+// Text sets the message of key as the text of the wrapped craft,
+// and sets it again on Update whenever the bundle's locale or messages change.
+// Text is transparent: other text is delegated to the wrapped craft.
+type Text[T craft.Craft] struct {
+	craft   T
+	bundle  *Bundle
+	key     string
+	args    Args
+	color   color.Color
+	version int
+}
+
+func NewText[T craft.Craft](craft T, bundle *Bundle, key string, args Args, color color.Color) *Text[T] {
+	t := &Text[T]{craft, bundle, key, args, color, bundle.Version()}
+	t.refresh()
+	return t
+}
+
+// SetArgs replaces the placeholder values and refreshes the text.
+func (t *Text[T]) SetArgs(args Args) *Text[T] {
+	t.args = args
+	t.refresh()
+	return t
+}
+
+func (t *Text[T]) refresh() {
+	t.version = t.bundle.Version()
+	t.craft.SetText(t.bundle.T(t.key, t.args), t.color)
+}
+
+func (t *Text[T]) Image() *ebiten.Image {
+	return t.craft.Image()
+}
+
+func (t *Text[T]) Size() types.Size {
+	return t.craft.Size()
+}
+
+func (t *Text[T]) AddText(str string, color color.Color) craft.Self {
+	t.craft.AddText(str, color)
+	return t
+}
+
+func (t *Text[T]) SetText(str string, color color.Color) craft.Self {
+	t.craft.SetText(str, color)
+	return t
+}
+
+func (t *Text[T]) ClearText() craft.Self {
+	t.craft.ClearText()
+	return t
+}
+
+func (t *Text[T]) SetDirection(d types.Direction) {
+	craft.SetDirection(t.craft, d)
+}
+
+func (t *Text[T]) Const() *craft.Image {
+	return t.craft.Const()
+}
+
+func (t *Text[T]) Update(p types.Position) error {
+	if t.version != t.bundle.Version() {
+		t.refresh()
+	}
+	return t.craft.Update(p)
+}
+
+func (t *Text[T]) Children() []event.Child {
+	return []event.Child{{Target: t.craft}}
+}
@@ -0,0 +1,40 @@
+package locale
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/types"
+)
+
+var _ craft.Craft = NewText(craft.NewFill(types.Size{X: 10, Y: 10}, color.White), New("en"), "title", nil, color.White)
+
+func TestTextUpdate(t *testing.T) {
+	b := New("en")
+	b.Add("en", Catalog{"title": {Other: "Title"}})
+	b.Add("ja", Catalog{"title": {Other: "タイトル"}})
+
+	var got string
+	c := &textCraft{Fill: craft.NewFill(types.Size{X: 10, Y: 10}, color.White), setText: func(s string) { got = s }}
+	text := NewText(c, b, "title", nil, color.White)
+	if got != "Title" {
+		t.Errorf("NewText should set the text, but got %q", got)
+	}
+
+	b.SetLocale("ja")
+	text.Update(types.Position{})
+	if got != "タイトル" {
+		t.Errorf("Update should set the text of the new locale, but got %q", got)
+	}
+}
+
+type textCraft struct {
+	*craft.Fill
+	setText func(string)
+}
+
+func (t *textCraft) SetText(str string, color color.Color) craft.Self {
+	t.setText(str)
+	return t
+}
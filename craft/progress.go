@@ -0,0 +1,359 @@
+package craft
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// DefaultProgressSpeed is how much of the whole range a progress craft moves per tick
+// toward a new value.
+const DefaultProgressSpeed = 0.02
+
+// progress is the value shared by ProgressBar and RadialGauge:
+// a target between 0 and 1, and the displayed value moving toward it.
+type progress struct {
+	value   float64
+	shown   float64
+	speed   float64
+	percent color.Color
+}
+
+func (p *progress) set(v float64) {
+	p.value = max(0, min(1, v))
+	if p.speed <= 0 {
+		p.shown = p.value
+	}
+}
+
+// step moves the displayed value a tick toward the target.
+func (p *progress) step() {
+	switch {
+	case p.shown < p.value:
+		p.shown = min(p.value, p.shown+p.speed)
+	case p.shown > p.value:
+		p.shown = max(p.value, p.shown-p.speed)
+	}
+}
+
+// drawPercent draws the displayed value as a percentage centered on image.
+func (p *progress) drawPercent(image *ebiten.Image) {
+	if p.percent == nil {
+		return
+	}
+	str := fmt.Sprintf("%d%%", int(math.Round(p.shown*100)))
+	size := image.Bounds().Size()
+	util.DrawTextAt(image, str, p.percent, types.Position{
+		X: (size.X - util.TextWidth(str)) / 2,
+		Y: (size.Y - util.LineHeight()) / 2,
+	})
+}
+
+// ProgressBar Craft
+//
+// ProgressBar fills from the start edge as its value goes from 0 to 1:
+// from the left, from the right in right-to-left text, or from the bottom when vertical.
+// A new value is reached smoothly, at the speed set by SetSpeed.
+// In segmented mode the bar is split in cells filled one by one, like a health bar.
+//
+// ```
+// [#####     ]
+// ```
+type ProgressBar struct {
+	progress
+	size        types.Size
+	orientation types.Orientation
+	reverse     bool
+	segments    int
+	color       color.Color
+	background  color.Color
+	texts       []types.TextInfo
+	direction   types.Direction
+}
+
+// progressSegmentGap is the space between the segments of a bar.
+const progressSegmentGap = 1
+
+func NewProgressBar(size types.Size, orientation types.Orientation, color color.Color) *ProgressBar {
+	return &ProgressBar{
+		progress:    progress{speed: DefaultProgressSpeed},
+		size:        size,
+		orientation: orientation,
+		color:       color,
+		background:  controlBackground,
+		texts:       []types.TextInfo{},
+	}
+}
+
+// SetValue sets the value between 0 and 1 the bar moves toward.
+func (b *ProgressBar) SetValue(v float64) *ProgressBar {
+	b.set(v)
+	return b
+}
+
+func (b *ProgressBar) Value() float64 {
+	return b.value
+}
+
+// Displayed returns the value currently drawn.
+func (b *ProgressBar) Displayed() float64 {
+	return b.shown
+}
+
+// SetSpeed sets how much of the bar is filled or emptied per tick. Zero jumps at once.
+func (b *ProgressBar) SetSpeed(speed float64) *ProgressBar {
+	b.speed = speed
+	b.set(b.value)
+	return b
+}
+
+// SetReverse fills the bar from the other edge.
+func (b *ProgressBar) SetReverse(reverse bool) *ProgressBar {
+	b.reverse = reverse
+	return b
+}
+
+// SetSegments splits the bar in n cells. Zero means a continuous bar.
+func (b *ProgressBar) SetSegments(n int) *ProgressBar {
+	b.segments = n
+	return b
+}
+
+func (b *ProgressBar) SetBackground(color color.Color) *ProgressBar {
+	b.background = color
+	return b
+}
+
+// ShowPercent draws the value as a percentage in color, or nothing if color is nil.
+func (b *ProgressBar) ShowPercent(color color.Color) *ProgressBar {
+	b.percent = color
+	return b
+}
+
+// fromEnd reports whether the bar fills from the right or the top.
+func (b *ProgressBar) fromEnd() bool {
+	end := b.orientation == types.Horizontal && b.direction == types.RightToLeft
+	return end != b.reverse
+}
+
+// cell is the rectangle of the part of the bar between from and to, fractions from the start edge.
+func (b *ProgressBar) cell(from, to float64) image.Rectangle {
+	if b.orientation == types.Vertical {
+		from, to = 1-to, 1-from
+	}
+	if b.fromEnd() {
+		from, to = 1-to, 1-from
+	}
+	if b.orientation == types.Vertical {
+		return image.Rect(0, int(math.Round(from*float64(b.size.Y))), b.size.X, int(math.Round(to*float64(b.size.Y))))
+	}
+	return image.Rect(int(math.Round(from*float64(b.size.X))), 0, int(math.Round(to*float64(b.size.X))), b.size.Y)
+}
+
+func (b *ProgressBar) Image() *ebiten.Image {
+	img := ebiten.NewImage(b.size.X, b.size.Y)
+	img.Fill(b.background)
+
+	fill := func(r image.Rectangle, inset int) {
+		if b.orientation == types.Vertical {
+			r.Min.Y, r.Max.Y = r.Min.Y+inset, r.Max.Y-inset
+		} else {
+			r.Min.X, r.Max.X = r.Min.X+inset, r.Max.X-inset
+		}
+		if !r.Empty() {
+			vector.DrawFilledRect(img, float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), float32(r.Dy()), b.color, false)
+		}
+	}
+	if b.segments > 0 {
+		n := float64(b.segments)
+		filled := int(math.Floor(b.shown*n + 1e-9))
+		for i := range filled {
+			fill(b.cell(float64(i)/n, float64(i+1)/n), progressSegmentGap)
+		}
+	} else {
+		fill(b.cell(0, b.shown), 0)
+	}
+
+	b.drawPercent(img)
+	util.DrawTexts(img, b.texts, b.direction)
+
+	return img
+}
+
+func (b *ProgressBar) Size() types.Size {
+	return b.size
+}
+
+func (b *ProgressBar) AddText(str string, color color.Color) Self {
+	b.texts = append(b.texts, types.TextInfo{Str: str, Color: color})
+	return b
+}
+
+func (b *ProgressBar) SetText(str string, color color.Color) Self {
+	b.texts = []types.TextInfo{{Str: str, Color: color}}
+	return b
+}
+
+func (b *ProgressBar) ClearText() Self {
+	b.texts = []types.TextInfo{}
+	return b
+}
+
+func (b *ProgressBar) SetDirection(d types.Direction) {
+	b.direction = d
+}
+
+func (b *ProgressBar) Const() *Image {
+	return &Image{b.Image(), []types.TextInfo{}, b.direction}
+}
+
+func (b *ProgressBar) Update(p types.Position) error {
+	b.step()
+	return nil
+}
+
+// RadialGauge Craft
+//
+// RadialGauge is a ring filled clockwise from the top as its value goes from 0 to 1,
+// such as a cooldown dial.
+// A new value is reached smoothly, like a ProgressBar.
+type RadialGauge struct {
+	progress
+	radius     int
+	thickness  float32
+	color      color.Color
+	background color.Color
+	texts      []types.TextInfo
+	direction  types.Direction
+}
+
+const defaultGaugeThickness = 4
+
+func NewRadialGauge(radius int, color color.Color) *RadialGauge {
+	return &RadialGauge{
+		progress:   progress{speed: DefaultProgressSpeed},
+		radius:     radius,
+		thickness:  defaultGaugeThickness,
+		color:      color,
+		background: controlBackground,
+		texts:      []types.TextInfo{},
+	}
+}
+
+// SetValue sets the value between 0 and 1 the gauge moves toward.
+func (g *RadialGauge) SetValue(v float64) *RadialGauge {
+	g.set(v)
+	return g
+}
+
+func (g *RadialGauge) Value() float64 {
+	return g.value
+}
+
+// Displayed returns the value currently drawn.
+func (g *RadialGauge) Displayed() float64 {
+	return g.shown
+}
+
+// SetSpeed sets how much of the ring is filled or emptied per tick. Zero jumps at once.
+func (g *RadialGauge) SetSpeed(speed float64) *RadialGauge {
+	g.speed = speed
+	g.set(g.value)
+	return g
+}
+
+func (g *RadialGauge) SetThickness(thickness float32) *RadialGauge {
+	g.thickness = thickness
+	return g
+}
+
+func (g *RadialGauge) SetBackground(color color.Color) *RadialGauge {
+	g.background = color
+	return g
+}
+
+// ShowPercent draws the value as a percentage in color, or nothing if color is nil.
+func (g *RadialGauge) ShowPercent(color color.Color) *RadialGauge {
+	g.percent = color
+	return g
+}
+
+func (g *RadialGauge) Image() *ebiten.Image {
+	size := g.Size()
+	image := ebiten.NewImage(size.X, size.Y)
+
+	c := float32(g.radius)
+	r := c - g.thickness/2
+	vector.StrokeCircle(image, c, c, r, g.thickness, g.background, true)
+	if g.shown > 0 {
+		start := float32(-math.Pi / 2)
+		strokeArc(image, c, c, r, start, start+float32(2*math.Pi*g.shown), g.thickness, g.color)
+	}
+
+	g.drawPercent(image)
+	util.DrawTexts(image, g.texts, g.direction)
+
+	return image
+}
+
+// whiteImage is the source of the triangles drawn by strokeArc.
+var whiteImage = func() *ebiten.Image {
+	img := ebiten.NewImage(3, 3)
+	img.Fill(color.White)
+	return img.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+}()
+
+// strokeArc strokes the arc of the circle (cx, cy, r) clockwise from start to end, in radians.
+func strokeArc(dst *ebiten.Image, cx, cy, r, start, end, width float32, clr color.Color) {
+	var path vector.Path
+	path.Arc(cx, cy, r, start, end, vector.Clockwise)
+	vs, is := path.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{Width: width})
+
+	cr, cg, cb, ca := clr.RGBA()
+	for i := range vs {
+		vs[i].SrcX, vs[i].SrcY = 1, 1
+		vs[i].ColorR = float32(cr) / 0xffff
+		vs[i].ColorG = float32(cg) / 0xffff
+		vs[i].ColorB = float32(cb) / 0xffff
+		vs[i].ColorA = float32(ca) / 0xffff
+	}
+	dst.DrawTriangles(vs, is, whiteImage, &ebiten.DrawTrianglesOptions{AntiAlias: true})
+}
+
+func (g *RadialGauge) Size() types.Size {
+	return types.Size{X: g.radius * 2, Y: g.radius * 2}
+}
+
+func (g *RadialGauge) AddText(str string, color color.Color) Self {
+	g.texts = append(g.texts, types.TextInfo{Str: str, Color: color})
+	return g
+}
+
+func (g *RadialGauge) SetText(str string, color color.Color) Self {
+	g.texts = []types.TextInfo{{Str: str, Color: color}}
+	return g
+}
+
+func (g *RadialGauge) ClearText() Self {
+	g.texts = []types.TextInfo{}
+	return g
+}
+
+func (g *RadialGauge) SetDirection(d types.Direction) {
+	g.direction = d
+}
+
+func (g *RadialGauge) Const() *Image {
+	return &Image{g.Image(), []types.TextInfo{}, g.direction}
+}
+
+func (g *RadialGauge) Update(p types.Position) error {
+	g.step()
+	return nil
+}
@@ -0,0 +1,82 @@
+package craft
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft/types"
+)
+
+var (
+	_ Craft = NewProgressBar(types.Size{X: 100, Y: 10}, types.Horizontal, color.White)
+	_ Craft = NewRadialGauge(10, color.White)
+)
+
+func TestProgressBarAnimation(t *testing.T) {
+	b := NewProgressBar(types.Size{X: 100, Y: 10}, types.Horizontal, color.White).SetSpeed(0.25).SetValue(0.6)
+
+	want := []float64{0.25, 0.5, 0.6, 0.6}
+	got := []float64{}
+	for range want {
+		b.Update(types.Position{})
+		got = append(got, b.Displayed())
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Displayed should return %v, but got %v", want, got)
+	}
+
+	b.SetValue(-1)
+	b.Update(types.Position{})
+	if b.Value() != 0 || b.Displayed() != 0.35 {
+		t.Errorf("SetValue(-1) should move toward 0, but got %v and %v", b.Value(), b.Displayed())
+	}
+
+	b.SetSpeed(0).SetValue(1)
+	if b.Displayed() != 1 {
+		t.Errorf("Displayed should return 1 without animation, but got %v", b.Displayed())
+	}
+}
+
+func TestProgressBarCell(t *testing.T) {
+	tests := []struct {
+		orientation types.Orientation
+		direction   types.Direction
+		reverse     bool
+		want        image.Rectangle
+	}{
+		{types.Horizontal, types.LeftToRight, false, image.Rect(0, 0, 25, 10)},
+		{types.Horizontal, types.LeftToRight, true, image.Rect(75, 0, 100, 10)},
+		{types.Horizontal, types.RightToLeft, false, image.Rect(75, 0, 100, 10)},
+		{types.Horizontal, types.RightToLeft, true, image.Rect(0, 0, 25, 10)},
+		{types.Vertical, types.LeftToRight, false, image.Rect(0, 75, 100, 100)},
+		{types.Vertical, types.LeftToRight, true, image.Rect(0, 0, 100, 25)},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			size := types.Size{X: 100, Y: 10}
+			if tt.orientation == types.Vertical {
+				size = types.Size{X: 100, Y: 100}
+			}
+			b := NewProgressBar(size, tt.orientation, color.White).SetReverse(tt.reverse)
+			SetDirection(b, tt.direction)
+			if got := b.cell(0, 0.25); got != tt.want {
+				t.Errorf("cell should return %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRadialGauge(t *testing.T) {
+	g := NewRadialGauge(10, color.White).SetSpeed(0.5).SetValue(1).ShowPercent(color.White)
+	g.Update(types.Position{})
+	if g.Displayed() != 0.5 {
+		t.Errorf("Displayed should return 0.5, but got %v", g.Displayed())
+	}
+	if size := g.Size(); size != (types.Size{X: 20, Y: 20}) {
+		t.Errorf("Size should return 20x20, but got %v", size)
+	}
+	g.Image()
+}
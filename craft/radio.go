@@ -0,0 +1,221 @@
+package craft
+
+import (
+	"image/color"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+func radioImage(on bool) Craft {
+	s := util.LineHeight()
+	r := float32(s) / 2
+	image := ebiten.NewImage(s, s)
+	vector.DrawFilledCircle(image, r, r, r-0.5, controlBackground, true)
+	vector.StrokeCircle(image, r, r, r-0.5, 1, controlBorder, true)
+	if on {
+		vector.DrawFilledCircle(image, r, r, r-4, controlAccent, true)
+	}
+	return &Image{image, []types.TextInfo{}, types.LeftToRight}
+}
+
+// radio is an option of a RadioGroup, focused and selected on its own.
+type radio struct {
+	check
+	group *RadioGroup
+	index int
+}
+
+func (r *radio) Focusable() bool {
+	return !r.disabled && !r.group.disabled
+}
+
+func (r *radio) CursorShape() ebiten.CursorShapeType {
+	if r.group.disabled {
+		return ebiten.CursorShapeNotAllowed
+	}
+	return r.check.CursorShape()
+}
+
+func (r *radio) HandleEvent(e *event.Event) error {
+	if r.group.disabled || !r.clicked(e) {
+		return nil
+	}
+	return r.group.choose(r.index)
+}
+
+type RadioGroupHandler func(*RadioGroup) error
+
+// RadioGroup Craft
+//
+// RadioGroup is a column of options of which exactly one is selected,
+// the first one at first.
+// Each option is focused on its own, so the arrow keys move between them,
+// and clicking or activating an option selects it.
+//
+// ```
+// (o) first
+// ( ) second
+// ```
+type RadioGroup struct {
+	options   []*radio
+	selected  int
+	disabled  bool
+	onChange  RadioGroupHandler
+	texts     []types.TextInfo
+	direction types.Direction
+}
+
+// radioGap is the space between the options.
+const radioGap = 2
+
+func NewRadioGroup(color color.Color, labels ...string) *RadioGroup {
+	g := &RadioGroup{texts: []types.TextInfo{}}
+	for i, label := range labels {
+		g.options = append(g.options, &radio{newCheck(radioImage(false), radioImage(true), label, color), g, i})
+	}
+	g.Select(0)
+	return g
+}
+
+// Selected returns the index of the selected option, or -1 without options.
+func (g *RadioGroup) Selected() int {
+	if len(g.options) == 0 {
+		return -1
+	}
+	return g.selected
+}
+
+// Value returns the label of the selected option.
+func (g *RadioGroup) Value() string {
+	if len(g.options) == 0 {
+		return ""
+	}
+	return g.options[g.selected].label
+}
+
+// Select selects the option i without calling the change handler.
+// Indexes out of range are ignored.
+func (g *RadioGroup) Select(i int) *RadioGroup {
+	if i < 0 || i >= len(g.options) {
+		return g
+	}
+	g.options[g.selected].set(false)
+	g.selected = i
+	g.options[i].set(true)
+	return g
+}
+
+// choose selects the option i and calls the change handler if the selection changed.
+func (g *RadioGroup) choose(i int) error {
+	if i == g.selected {
+		return nil
+	}
+	g.Select(i)
+	if g.onChange != nil {
+		return g.onChange(g)
+	}
+	return nil
+}
+
+func (g *RadioGroup) SetDisabled(disabled bool) *RadioGroup {
+	g.disabled = disabled
+	return g
+}
+
+func (g *RadioGroup) Disabled() bool {
+	return g.disabled
+}
+
+// SetOptionDisabled disables the option i, which can then not be selected by the user.
+func (g *RadioGroup) SetOptionDisabled(i int, disabled bool) *RadioGroup {
+	if 0 <= i && i < len(g.options) {
+		g.options[i].disabled = disabled
+		g.options[i].pressed = false
+	}
+	return g
+}
+
+func (g *RadioGroup) OnChange(handler RadioGroupHandler) *RadioGroup {
+	g.onChange = handler
+	return g
+}
+
+func (g *RadioGroup) Image() *ebiten.Image {
+	size := g.Size()
+	image := ebiten.NewImage(max(size.X, 1), max(size.Y, 1))
+	for _, c := range g.Children() {
+		r := c.Target.(*radio)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(c.Position.X), float64(c.Position.Y))
+		image.DrawImage(r.Image(), op)
+	}
+	if g.disabled {
+		vector.DrawFilledRect(image, 0, 0, float32(size.X), float32(size.Y), controlDisabled, false)
+	}
+	util.DrawTexts(image, g.texts, g.direction)
+
+	return image
+}
+
+func (g *RadioGroup) Size() types.Size {
+	size := types.Size{}
+	for i, r := range g.options {
+		s := r.Size()
+		size.X = max(size.X, s.X)
+		size.Y += s.Y
+		if i > 0 {
+			size.Y += radioGap
+		}
+	}
+	return size
+}
+
+// Children are the options, aligned to the start edge.
+func (g *RadioGroup) Children() []event.Child {
+	width := g.Size().X
+	children := make([]event.Child, len(g.options))
+	y := 0
+	for i, r := range g.options {
+		p := types.Position{Y: y}
+		if g.direction == types.RightToLeft {
+			p.X = width - r.Size().X
+		}
+		children[i] = event.Child{Target: r, Position: p}
+		y += r.Size().Y + radioGap
+	}
+	return children
+}
+
+func (g *RadioGroup) AddText(str string, color color.Color) Self {
+	g.texts = append(g.texts, types.TextInfo{Str: str, Color: color})
+	return g
+}
+
+func (g *RadioGroup) SetText(str string, color color.Color) Self {
+	g.texts = []types.TextInfo{{Str: str, Color: color}}
+	return g
+}
+
+func (g *RadioGroup) ClearText() Self {
+	g.texts = []types.TextInfo{}
+	return g
+}
+
+func (g *RadioGroup) SetDirection(d types.Direction) {
+	g.direction = d
+	for _, r := range g.options {
+		r.SetDirection(d)
+	}
+}
+
+func (g *RadioGroup) Const() *Image {
+	return &Image{g.Image(), []types.TextInfo{}, g.direction}
+}
+
+func (g *RadioGroup) Update(p types.Position) error {
+	return nil
+}
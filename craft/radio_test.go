@@ -0,0 +1,78 @@
+package craft
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ Craft = NewRadioGroup(color.White, "a", "b")
+
+func TestRadioGroup(t *testing.T) {
+	changes := []int{}
+	g := NewRadioGroup(color.White, "first", "second", "third").
+		SetOptionDisabled(2, true).
+		OnChange(func(g *RadioGroup) error { changes = append(changes, g.Selected()); return nil })
+	in := input.New()
+	d := event.NewDispatcher()
+
+	// options are 16 high with a gap of 2
+	tests := []struct {
+		y    int
+		want int
+	}{
+		{20, 1},
+		{20, 1},
+		{5, 0},
+		{38, 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			click(d, g, in, types.Position{X: 5, Y: tt.y})
+			if got := g.Selected(); got != tt.want {
+				t.Errorf("Selected should return %d, but got %d", tt.want, got)
+			}
+			checked := 0
+			for _, r := range g.options {
+				if r.Checked() {
+					checked++
+				}
+			}
+			if checked != 1 {
+				t.Errorf("exactly 1 option should be checked, but got %d", checked)
+			}
+		})
+	}
+
+	if fmt.Sprint(changes) != "[1 0]" {
+		t.Errorf("handler should be called with [1 0], but got %v", changes)
+	}
+}
+
+func TestRadioGroupKeyboard(t *testing.T) {
+	g := NewRadioGroup(color.White, "first", "second")
+	in := input.New()
+	d := event.NewDispatcher()
+
+	for _, k := range []ebiten.Key{ebiten.KeyTab, ebiten.KeyArrowDown, ebiten.KeySpace} {
+		in.Update(input.State{Keys: []ebiten.Key{k}})
+		d.Dispatch(g, in)
+		in.Update(input.State{})
+		d.Dispatch(g, in)
+	}
+
+	if g.Value() != "second" {
+		t.Errorf("Value should return %q, but got %q", "second", g.Value())
+	}
+
+	g.SetDisabled(true)
+	if g.options[0].Focusable() {
+		t.Errorf("Focusable should return false when the group is disabled, but got true")
+	}
+}
@@ -0,0 +1,291 @@
+package craft
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type SliderHandler func(s *Slider, value float64) error
+
+// Slider Craft
+//
+// Slider picks a value between min and max by dragging the thumb or clicking the track.
+// While focused, the actions along the slider, the arrow keys and the D-pad by default, move it by a step,
+// Page Up and Page Down by ten steps, and Home and End to the ends;
+// the other directions still move the focus.
+// A horizontal slider grows to the right, or to the left in right-to-left text,
+// and a vertical one grows upward.
+//
+// ```
+// ====[]------
+// ```
+type Slider struct {
+	orientation types.Orientation
+	length      int
+	min         float64
+	max         float64
+	step        float64
+	value       float64
+	dragging    bool
+	disabled    bool
+	onChange    SliderHandler
+	texts       []types.TextInfo
+	direction   types.Direction
+}
+
+const (
+	sliderThickness = 16
+	sliderThumb     = 8
+	sliderTrack     = 4
+	// sliderKeySteps is the number of key presses across a slider without a step.
+	sliderKeySteps = 100
+)
+
+func NewSlider(orientation types.Orientation, length int, min, max float64) *Slider {
+	return &Slider{
+		orientation: orientation,
+		length:      length,
+		min:         min,
+		max:         max,
+		value:       min,
+		texts:       []types.TextInfo{},
+	}
+}
+
+// SetStep snaps the value to multiples of step from min. Zero means no snapping.
+func (s *Slider) SetStep(step float64) *Slider {
+	s.step = step
+	s.value = s.clamp(s.value)
+	return s
+}
+
+// SetValue sets the value, clamped and snapped, without calling the change handler.
+func (s *Slider) SetValue(v float64) *Slider {
+	s.value = s.clamp(v)
+	return s
+}
+
+func (s *Slider) Value() float64 {
+	return s.value
+}
+
+func (s *Slider) SetDisabled(disabled bool) *Slider {
+	s.disabled = disabled
+	s.dragging = false
+	return s
+}
+
+func (s *Slider) Disabled() bool {
+	return s.disabled
+}
+
+func (s *Slider) OnChange(handler SliderHandler) *Slider {
+	s.onChange = handler
+	return s
+}
+
+func (s *Slider) clamp(v float64) float64 {
+	if s.step > 0 {
+		v = s.min + math.Round((v-s.min)/s.step)*s.step
+	}
+	return max(s.min, min(s.max, v))
+}
+
+// change sets the value and calls the change handler if it changed.
+func (s *Slider) change(v float64) error {
+	v = s.clamp(v)
+	if v == s.value {
+		return nil
+	}
+	s.value = v
+	if s.onChange != nil {
+		return s.onChange(s, v)
+	}
+	return nil
+}
+
+// keyStep is how far a key press moves the value.
+func (s *Slider) keyStep() float64 {
+	if s.step > 0 {
+		return s.step
+	}
+	return (s.max - s.min) / sliderKeySteps
+}
+
+// usable is the length the thumb can travel.
+func (s *Slider) usable() int {
+	return max(s.length-sliderThumb, 1)
+}
+
+// offset is the distance of the thumb from the start of the slider.
+func (s *Slider) offset() int {
+	if s.max <= s.min {
+		return 0
+	}
+	return int(math.Round((s.value - s.min) / (s.max - s.min) * float64(s.usable())))
+}
+
+// valueAt is the value with the thumb centered at p.
+func (s *Slider) valueAt(p types.Position) float64 {
+	along := p.X
+	switch {
+	case s.orientation == types.Vertical:
+		along = s.length - p.Y
+	case s.direction == types.RightToLeft:
+		along = s.length - p.X
+	}
+	t := float64(along-sliderThumb/2) / float64(s.usable())
+	return s.min + max(0, min(1, t))*(s.max-s.min)
+}
+
+// thumb is the top-left corner of the thumb.
+func (s *Slider) thumb() types.Position {
+	o := s.offset()
+	switch {
+	case s.orientation == types.Vertical:
+		return types.Position{Y: s.length - sliderThumb - o}
+	case s.direction == types.RightToLeft:
+		return types.Position{X: s.length - sliderThumb - o}
+	}
+	return types.Position{X: o}
+}
+
+func (s *Slider) Image() *ebiten.Image {
+	size := s.Size()
+	image := ebiten.NewImage(size.X, size.Y)
+
+	thumb := s.thumb()
+	const mid = (sliderThickness - sliderTrack) / 2
+	if s.orientation == types.Vertical {
+		vector.DrawFilledRect(image, mid, 0, sliderTrack, float32(s.length), controlBackground, false)
+		vector.DrawFilledRect(image, mid, float32(thumb.Y), sliderTrack, float32(s.length-thumb.Y), controlAccent, false)
+		vector.DrawFilledRect(image, 0, float32(thumb.Y), sliderThickness, sliderThumb, color.White, false)
+	} else {
+		vector.DrawFilledRect(image, 0, mid, float32(s.length), sliderTrack, controlBackground, false)
+		if s.direction == types.RightToLeft {
+			vector.DrawFilledRect(image, float32(thumb.X), mid, float32(s.length-thumb.X), sliderTrack, controlAccent, false)
+		} else {
+			vector.DrawFilledRect(image, 0, mid, float32(thumb.X+sliderThumb), sliderTrack, controlAccent, false)
+		}
+		vector.DrawFilledRect(image, float32(thumb.X), 0, sliderThumb, sliderThickness, color.White, false)
+	}
+	if s.disabled {
+		vector.DrawFilledRect(image, 0, 0, float32(size.X), float32(size.Y), controlDisabled, false)
+	}
+	util.DrawTexts(image, s.texts, s.direction)
+
+	return image
+}
+
+func (s *Slider) Size() types.Size {
+	if s.orientation == types.Vertical {
+		return types.Size{X: sliderThickness, Y: s.length}
+	}
+	return types.Size{X: s.length, Y: sliderThickness}
+}
+
+func (s *Slider) AddText(str string, color color.Color) Self {
+	s.texts = append(s.texts, types.TextInfo{Str: str, Color: color})
+	return s
+}
+
+func (s *Slider) SetText(str string, color color.Color) Self {
+	s.texts = []types.TextInfo{{Str: str, Color: color}}
+	return s
+}
+
+func (s *Slider) ClearText() Self {
+	s.texts = []types.TextInfo{}
+	return s
+}
+
+func (s *Slider) SetDirection(d types.Direction) {
+	s.direction = d
+}
+
+func (s *Slider) Const() *Image {
+	return &Image{s.Image(), []types.TextInfo{}, s.direction}
+}
+
+func (s *Slider) Update(p types.Position) error {
+	return nil
+}
+
+func (s *Slider) Focusable() bool {
+	return !s.disabled
+}
+
+func (s *Slider) CursorShape() ebiten.CursorShapeType {
+	if s.disabled {
+		return ebiten.CursorShapeNotAllowed
+	}
+	return ebiten.CursorShapePointer
+}
+
+func (s *Slider) HandleEvent(e *event.Event) error {
+	if s.disabled {
+		return nil
+	}
+	switch e.Type {
+	case event.PointerDown:
+		if e.Button != ebiten.MouseButtonLeft {
+			return nil
+		}
+		s.dragging = true
+		e.StopPropagation()
+		return s.change(s.valueAt(e.Local()))
+	case event.PointerMove:
+		if s.dragging {
+			return s.change(s.valueAt(e.Local()))
+		}
+	case event.PointerUp:
+		s.dragging = false
+	case event.KeyDown, event.GamepadDown:
+		v, ok := s.adjust(e)
+		if !ok {
+			return nil
+		}
+		e.StopPropagation()
+		return s.change(v)
+	}
+	return nil
+}
+
+// adjust returns the value a key or a gamepad button moves the slider to,
+// or false for inputs the slider does not use.
+func (s *Slider) adjust(e *event.Event) (float64, bool) {
+	actions := e.Dispatcher().Actions()
+	up, down := input.ActionRight, input.ActionLeft
+	switch {
+	case s.orientation == types.Vertical:
+		up, down = input.ActionUp, input.ActionDown
+	case s.direction == types.RightToLeft:
+		up, down = down, up
+	}
+
+	step := s.keyStep()
+	switch {
+	case e.Is(actions, up):
+		return s.value + step, true
+	case e.Is(actions, down):
+		return s.value - step, true
+	case e.Type != event.KeyDown:
+		return 0, false
+	case e.Key == ebiten.KeyPageUp:
+		return s.value + step*10, true
+	case e.Key == ebiten.KeyPageDown:
+		return s.value - step*10, true
+	case e.Key == ebiten.KeyHome:
+		return s.min, true
+	case e.Key == ebiten.KeyEnd:
+		return s.max, true
+	}
+	return 0, false
+}
@@ -0,0 +1,112 @@
+package craft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ Craft = NewSlider(types.Horizontal, 100, 0, 1)
+
+func TestSliderSetValue(t *testing.T) {
+	tests := []struct {
+		step  float64
+		value float64
+		want  float64
+	}{
+		{0, 0.33, 0.33},
+		{0, -1, 0},
+		{0, 2, 1},
+		{0.25, 0.33, 0.25},
+		{0.25, 0.4, 0.5},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			s := NewSlider(types.Horizontal, 100, 0, 1).SetStep(tt.step).SetValue(tt.value)
+			if got := s.Value(); got != tt.want {
+				t.Errorf("Value should return %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSliderPointer(t *testing.T) {
+	tests := []struct {
+		orientation types.Orientation
+		direction   types.Direction
+		positions   []types.Position
+		want        []float64
+	}{
+		// the thumb travels 100 - 8 pixels from x = 4
+		{types.Horizontal, types.LeftToRight, []types.Position{{X: 50, Y: 5}, {X: 96, Y: 5}, {X: 200, Y: 5}}, []float64{50, 100}},
+		{types.Horizontal, types.RightToLeft, []types.Position{{X: 4, Y: 5}}, []float64{100}},
+		{types.Vertical, types.LeftToRight, []types.Position{{X: 5, Y: 96}, {X: 5, Y: 4}}, []float64{0, 100}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			var got []float64
+			s := NewSlider(tt.orientation, 100, 0, 100).SetStep(10).SetValue(20).
+				OnChange(func(s *Slider, v float64) error { got = append(got, v); return nil })
+			SetDirection(s, tt.direction)
+
+			in := input.New()
+			d := event.NewDispatcher()
+			left := []ebiten.MouseButton{ebiten.MouseButtonLeft}
+			for _, p := range tt.positions {
+				in.Update(input.State{Cursor: p, Buttons: left})
+				d.Dispatch(s, in)
+			}
+			in.Update(input.State{Cursor: tt.positions[len(tt.positions)-1]})
+			d.Dispatch(s, in)
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("handler should be called with %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSliderKeys(t *testing.T) {
+	s := NewSlider(types.Horizontal, 100, 0, 10).SetStep(1).SetValue(5)
+	in := input.New()
+	d := event.NewDispatcher().SetActions(input.DefaultMap().Bind(input.ActionRight, input.KeyBinding(ebiten.KeyL)))
+	d.SetFocus(s)
+
+	tests := []struct {
+		key  ebiten.Key
+		want float64
+	}{
+		{ebiten.KeyArrowRight, 6},
+		{ebiten.KeyL, 7},
+		{ebiten.KeyArrowLeft, 6},
+		{ebiten.KeyArrowLeft, 5},
+		{ebiten.KeyArrowUp, 5},
+		{ebiten.KeyEnd, 10},
+		{ebiten.KeyArrowRight, 10},
+		{ebiten.KeyPageDown, 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			in.Update(input.State{Keys: []ebiten.Key{tt.key}})
+			d.Dispatch(s, in)
+			in.Update(input.State{})
+			d.Dispatch(s, in)
+			if got := s.Value(); got != tt.want {
+				t.Errorf("Value should return %v, but got %v", tt.want, got)
+			}
+		})
+	}
+
+	in.Update(input.State{Gamepads: []input.Gamepad{{Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftRight}}}})
+	d.Dispatch(s, in)
+	if got := s.Value(); got != 1 {
+		t.Errorf("Value should return 1, but got %v", got)
+	}
+}
@@ -0,0 +1,398 @@
+package craft
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// tabPadding is the space around the icon and the label of a tab.
+const tabPadding = 4
+
+// tab is a header of Tabs and the content it shows.
+type tab struct {
+	label   string
+	icon    Craft
+	content Craft
+}
+
+// width is the width of the header.
+func (t tab) width() int {
+	w := util.TextWidth(t.label)
+	if t.icon != nil {
+		w += t.icon.Size().X
+		if t.label != "" {
+			w += labelGap
+		}
+	}
+	return w + tabPadding*2
+}
+
+// tabStrip is the row of headers of Tabs, focused as one craft.
+type tabStrip struct {
+	tabs *Tabs
+}
+
+func (s *tabStrip) Size() types.Size {
+	return types.Size{X: s.tabs.Size().X, Y: s.tabs.stripHeight()}
+}
+
+func (s *tabStrip) Focusable() bool {
+	return len(s.tabs.tabs) > 0
+}
+
+func (s *tabStrip) CursorShape() ebiten.CursorShapeType {
+	return ebiten.CursorShapePointer
+}
+
+func (s *tabStrip) HandleEvent(e *event.Event) error {
+	t := s.tabs
+	switch e.Type {
+	case event.PointerDown:
+		if e.Button != ebiten.MouseButtonLeft {
+			return nil
+		}
+		i := t.headerAt(e.Local().X)
+		if i < 0 {
+			return nil
+		}
+		e.StopPropagation()
+		return t.choose(i)
+	case event.KeyDown, event.GamepadDown:
+		i, ok := s.move(e)
+		if !ok {
+			return nil
+		}
+		e.StopPropagation()
+		return t.choose(i)
+	}
+	return nil
+}
+
+// move returns the tab a key or a gamepad button selects in the strip,
+// or false for inputs the strip does not use.
+func (s *tabStrip) move(e *event.Event) (int, bool) {
+	t := s.tabs
+	n := len(t.tabs)
+	if n == 0 {
+		return 0, false
+	}
+	actions := e.Dispatcher().Actions()
+	next, prev := input.ActionRight, input.ActionLeft
+	if t.direction == types.RightToLeft {
+		next, prev = prev, next
+	}
+
+	switch {
+	case e.Is(actions, next):
+		return (t.selected + 1) % n, true
+	case e.Is(actions, prev):
+		return (t.selected + n - 1) % n, true
+	case e.Type != event.KeyDown:
+		return 0, false
+	case e.Key == ebiten.KeyHome:
+		return 0, true
+	case e.Key == ebiten.KeyEnd:
+		return n - 1, true
+	}
+	return 0, false
+}
+
+type TabsHandler func(*Tabs) error
+
+// Tabs Craft
+//
+// Tabs shows the content of the selected tab below a strip of headers,
+// each with a label and an optional icon.
+// Pressing a header selects its tab.
+// The strip is focused as one craft, and the left and right actions select
+// the previous and next tabs in it, wrapping around.
+// From anywhere inside, Ctrl+Tab and Ctrl+Shift+Tab, Ctrl+PageDown and Ctrl+PageUp,
+// and the front top buttons of a gamepad cycle the tabs as well.
+// Tabs is as large as its largest content, so that it does not resize when switched.
+//
+// ```
+// +-----+-----+-----+
+// | one | two |     |
+// +-----+-----+-----+
+// |                 |
+// |     content     |
+// |                 |
+// +-----------------+
+// ```
+type Tabs struct {
+	tabs      []tab
+	strip     *tabStrip
+	selected  int
+	color     color.Color
+	onChange  TabsHandler
+	texts     []types.TextInfo
+	direction types.Direction
+}
+
+func NewTabs(color color.Color) *Tabs {
+	t := &Tabs{color: color, texts: []types.TextInfo{}}
+	t.strip = &tabStrip{t}
+	return t
+}
+
+// AddTab adds a tab showing content, labelled with label.
+func (t *Tabs) AddTab(label string, content Craft) *Tabs {
+	t.tabs = append(t.tabs, tab{label: label, content: SetDirection(content, t.direction)})
+	return t
+}
+
+// SetIcon sets the icon drawn before the label of the tab i.
+// Indexes out of range are ignored.
+func (t *Tabs) SetIcon(i int, icon Craft) *Tabs {
+	if 0 <= i && i < len(t.tabs) {
+		t.tabs[i].icon = icon
+	}
+	return t
+}
+
+func (t *Tabs) Len() int {
+	return len(t.tabs)
+}
+
+// Selected returns the index of the selected tab, or -1 without tabs.
+func (t *Tabs) Selected() int {
+	if len(t.tabs) == 0 {
+		return -1
+	}
+	return t.selected
+}
+
+// Label returns the label of the tab i.
+func (t *Tabs) Label(i int) string {
+	if i < 0 || i >= len(t.tabs) {
+		return ""
+	}
+	return t.tabs[i].label
+}
+
+// Content returns the content of the selected tab, or nil without tabs.
+func (t *Tabs) Content() Craft {
+	if len(t.tabs) == 0 {
+		return nil
+	}
+	return t.tabs[t.selected].content
+}
+
+// Select selects the tab i without calling the change handler.
+// Indexes out of range are ignored.
+func (t *Tabs) Select(i int) *Tabs {
+	if 0 <= i && i < len(t.tabs) {
+		t.selected = i
+	}
+	return t
+}
+
+// choose selects the tab i and calls the change handler if the selection changed.
+func (t *Tabs) choose(i int) error {
+	if i == t.selected {
+		return nil
+	}
+	t.Select(i)
+	if t.onChange != nil {
+		return t.onChange(t)
+	}
+	return nil
+}
+
+func (t *Tabs) OnChange(handler TabsHandler) *Tabs {
+	t.onChange = handler
+	return t
+}
+
+func (t *Tabs) stripHeight() int {
+	h := util.LineHeight()
+	for _, tab := range t.tabs {
+		if tab.icon != nil {
+			h = max(h, tab.icon.Size().Y)
+		}
+	}
+	return h + tabPadding*2
+}
+
+// headerX returns the x coordinate of the header i, mirrored when right-to-left.
+func (t *Tabs) headerX(i int) int {
+	x := 0
+	for _, tab := range t.tabs[:i] {
+		x += tab.width()
+	}
+	if t.direction == types.RightToLeft {
+		return t.Size().X - x - t.tabs[i].width()
+	}
+	return x
+}
+
+// headerAt returns the index of the header at x, or -1.
+func (t *Tabs) headerAt(x int) int {
+	for i, tab := range t.tabs {
+		if from := t.headerX(i); from <= x && x < from+tab.width() {
+			return i
+		}
+	}
+	return -1
+}
+
+// iconAt returns the position of the icon of the tab i.
+// The icon comes before the label, so after it in right-to-left text.
+func (t *Tabs) iconAt(i int) types.Position {
+	tab := t.tabs[i]
+	p := types.Position{X: t.headerX(i) + tabPadding, Y: (t.stripHeight() - tab.icon.Size().Y) / 2}
+	if t.direction == types.RightToLeft {
+		p.X += tab.width() - tabPadding*2 - tab.icon.Size().X
+	}
+	return p
+}
+
+func (t *Tabs) Image() *ebiten.Image {
+	size := t.Size()
+	image := ebiten.NewImage(max(size.X, 1), max(size.Y, 1))
+	h := t.stripHeight()
+
+	vector.DrawFilledRect(image, 0, 0, float32(size.X), float32(h), controlBackground, false)
+	vector.DrawFilledRect(image, 0, float32(h-1), float32(size.X), 1, controlBorder, false)
+	for i, tab := range t.tabs {
+		x := t.headerX(i)
+		if i == t.selected {
+			vector.DrawFilledRect(image, float32(x), float32(h-2), float32(tab.width()), 2, controlAccent, false)
+		}
+
+		labelX := x + tabPadding
+		if tab.icon != nil {
+			p := t.iconAt(i)
+			if t.direction != types.RightToLeft {
+				labelX += tab.icon.Size().X + labelGap
+			}
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Translate(float64(p.X), float64(p.Y))
+			image.DrawImage(tab.icon.Image(), op)
+		}
+		if tab.label != "" {
+			util.DrawTextAt(image, tab.label, t.color, types.Position{X: labelX, Y: (h - util.LineHeight()) / 2})
+		}
+	}
+
+	if c := t.Content(); c != nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(0, float64(h))
+		image.DrawImage(c.Image(), op)
+	}
+	util.DrawTexts(image, t.texts, t.direction)
+
+	return image
+}
+
+func (t *Tabs) Size() types.Size {
+	size := types.Size{}
+	for _, tab := range t.tabs {
+		s := tab.content.Size()
+		size.X = max(size.X, s.X)
+		size.Y = max(size.Y, s.Y)
+	}
+	width := 0
+	for _, tab := range t.tabs {
+		width += tab.width()
+	}
+	size.X = max(size.X, width)
+	size.Y += t.stripHeight()
+	return size
+}
+
+// Children are the strip and the content of the selected tab.
+func (t *Tabs) Children() []event.Child {
+	children := []event.Child{{Target: t.strip}}
+	if c := t.Content(); c != nil {
+		children = append(children, event.Child{Target: c, Position: types.Position{Y: t.stripHeight()}})
+	}
+	return children
+}
+
+func (t *Tabs) AddText(str string, color color.Color) Self {
+	t.texts = append(t.texts, types.TextInfo{Str: str, Color: color})
+	return t
+}
+
+func (t *Tabs) SetText(str string, color color.Color) Self {
+	t.texts = []types.TextInfo{{Str: str, Color: color}}
+	return t
+}
+
+func (t *Tabs) ClearText() Self {
+	t.texts = []types.TextInfo{}
+	return t
+}
+
+func (t *Tabs) SetDirection(d types.Direction) {
+	t.direction = d
+	for _, tab := range t.tabs {
+		SetDirection(tab.content, d)
+		if tab.icon != nil {
+			SetDirection(tab.icon, d)
+		}
+	}
+}
+
+func (t *Tabs) Const() *Image {
+	return &Image{t.Image(), []types.TextInfo{}, t.direction}
+}
+
+func (t *Tabs) Update(p types.Position) (err error) {
+	for i, tab := range t.tabs {
+		if tab.icon != nil {
+			err = errors.Join(err, tab.icon.Update(p.Add(t.iconAt(i))))
+		}
+	}
+	if c := t.Content(); c != nil {
+		err = errors.Join(err, c.Update(p.Add(types.Position{Y: t.stripHeight()})))
+	}
+	return err
+}
+
+// HandleEvent cycles the tabs with the keys and gamepad buttons
+// no craft inside stopped.
+// The focus moves to the strip, as the content it was in is switched away.
+func (t *Tabs) HandleEvent(e *event.Event) error {
+	n := len(t.tabs)
+	if n == 0 || e.Phase == event.Capture {
+		return nil
+	}
+	delta := 0
+	switch e.Type {
+	case event.KeyDown:
+		if !e.Has(event.Control) {
+			return nil
+		}
+		switch {
+		case e.Key == ebiten.KeyTab && e.Has(event.Shift), e.Key == ebiten.KeyPageUp:
+			delta = -1
+		case e.Key == ebiten.KeyTab, e.Key == ebiten.KeyPageDown:
+			delta = 1
+		}
+	case event.GamepadDown:
+		switch e.GamepadButton {
+		case ebiten.StandardGamepadButtonFrontTopLeft:
+			delta = -1
+		case ebiten.StandardGamepadButtonFrontTopRight:
+			delta = 1
+		}
+	}
+	if delta == 0 {
+		return nil
+	}
+	e.StopPropagation()
+	err := t.choose((t.selected + delta + n) % n)
+	if e.Target() != t && e.Target() != t.strip {
+		err = errors.Join(err, e.Dispatcher().SetFocus(t.strip))
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package craft
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ Craft = NewTabs(color.White).AddTab("a", NewFill(types.Size{X: 10, Y: 10}, color.White))
+
+func newTestTabs() *Tabs {
+	return NewTabs(color.White).
+		AddTab("one", NewFill(types.Size{X: 100, Y: 50}, color.White)).
+		AddTab("two", NewFill(types.Size{X: 80, Y: 60}, color.White)).
+		AddTab("three", NewFill(types.Size{X: 10, Y: 10}, color.White))
+}
+
+func TestTabsSize(t *testing.T) {
+	tabs := newTestTabs()
+	want := types.Size{X: 100, Y: 60 + util.LineHeight() + tabPadding*2}
+
+	for i := range tabs.Len() {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			tabs.Select(i)
+			if got := tabs.Size(); got != want {
+				t.Errorf("Size should return %v, but got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestTabsPointer(t *testing.T) {
+	changes := []int{}
+	tabs := newTestTabs().OnChange(func(t *Tabs) error { changes = append(changes, t.Selected()); return nil })
+	in := input.New()
+	d := event.NewDispatcher()
+
+	one := util.TextWidth("one") + tabPadding*2
+	two := util.TextWidth("two") + tabPadding*2
+	tests := []struct {
+		direction types.Direction
+		x         int
+		want      int
+	}{
+		{types.LeftToRight, one + 1, 1},
+		{types.LeftToRight, one + two + 1, 2},
+		{types.LeftToRight, 1, 0},
+		{types.RightToLeft, 99, 0},
+		{types.RightToLeft, 99 - one, 1},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			SetDirection(tabs, tt.direction)
+			click(d, tabs, in, types.Position{X: tt.x, Y: 2})
+			if got := tabs.Selected(); got != tt.want {
+				t.Errorf("Selected should return %d, but got %d", tt.want, got)
+			}
+			if got := tabs.Children()[1].Target; got != tabs.Content() {
+				t.Errorf("Children should contain %v, but got %v", tabs.Content(), got)
+			}
+		})
+	}
+
+	if fmt.Sprint(changes) != "[1 2 0 1]" {
+		t.Errorf("handler should be called with [1 2 0 1], but got %v", changes)
+	}
+}
+
+func TestTabsKeys(t *testing.T) {
+	button := NewButton(NewFill(types.Size{X: 10, Y: 10}, color.White), nil)
+	tabs := newTestTabs().AddTab("four", button)
+	in := input.New()
+	d := event.NewDispatcher().SetActions(input.DefaultMap().Bind(input.ActionRight, input.KeyBinding(ebiten.KeyL)))
+	press := func(s input.State) {
+		in.Update(s)
+		d.Dispatch(tabs, in)
+		in.Update(input.State{})
+		d.Dispatch(tabs, in)
+	}
+	key := func(keys ...ebiten.Key) input.State {
+		return input.State{Keys: keys}
+	}
+	pad := func(b ebiten.StandardGamepadButton) input.State {
+		return input.State{Gamepads: []input.Gamepad{{Buttons: []ebiten.StandardGamepadButton{b}}}}
+	}
+
+	tests := []struct {
+		input input.State
+		want  int
+	}{
+		{key(ebiten.KeyTab), 0},
+		{key(ebiten.KeyArrowRight), 1},
+		{key(ebiten.KeyL), 2},
+		{key(ebiten.KeyArrowLeft), 1},
+		{key(ebiten.KeyArrowLeft), 0},
+		{key(ebiten.KeyArrowLeft), 3},
+		{key(ebiten.KeyHome), 0},
+		{key(ebiten.KeyEnd), 3},
+		{pad(ebiten.StandardGamepadButtonLeftRight), 0},
+		{pad(ebiten.StandardGamepadButtonFrontTopLeft), 3},
+		// the button in the content is focused
+		{key(ebiten.KeyTab), 3},
+		{key(ebiten.KeyControl, ebiten.KeyTab), 0},
+		{key(ebiten.KeyControl, ebiten.KeyShift, ebiten.KeyTab), 3},
+		{key(ebiten.KeyControl, ebiten.KeyPageUp), 2},
+		{pad(ebiten.StandardGamepadButtonFrontTopRight), 3},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			press(tt.input)
+			if got := tabs.Selected(); got != tt.want {
+				t.Errorf("Selected should return %d, but got %d", tt.want, got)
+			}
+		})
+	}
+
+	if d.Focused() != tabs.strip {
+		t.Errorf("Focused should return the strip, but got %v", d.Focused())
+	}
+}
@@ -25,6 +25,14 @@ func (t *testingCraft) AddText(s string, c color.Color) Self {
 	return t
 }
 
+func (t *testingCraft) SetText(s string, c color.Color) Self {
+	return t
+}
+
+func (t *testingCraft) ClearText() Self {
+	return t
+}
+
 func (t *testingCraft) Const() *Image {
 	return &Image{}
 }
@@ -0,0 +1,465 @@
+package craft
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"unicode"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/textedit"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type TextAreaHandler func(*TextArea) error
+
+// TextArea Craft
+//
+// TextArea is a multi-line text field with soft wrapping, vertical scrolling,
+// an optional line number gutter and undo/redo.
+//
+// ```
+// +--+------------+
+// | 1|first line  |
+// | 2|second line |
+// |  |wrapped|    |
+// +--+------------+
+// ```
+type TextArea struct {
+	size        types.Size
+	color       color.Color
+	buffer      *textedit.Buffer
+	history     *textedit.History
+	lineNumbers bool
+	focused     bool
+	dragging    bool
+	top         int
+	// wheel is the part of a line scrolled by the wheel, kept until it adds up to a whole line.
+	wheel    float64
+	ticks    int
+	onChange TextAreaHandler
+	texts    []types.TextInfo
+	wrapped  wrapping
+}
+
+// wrapping keeps the text wrapped last,
+// wrapped again only when the text, the width or the face changes.
+type wrapping struct {
+	text  []rune
+	face  text.Face
+	width int
+	lines []textedit.Line
+	// numbers is the number of logical lines.
+	numbers int
+}
+
+const (
+	textAreaPadding = 2
+	historyLimit    = 100
+)
+
+var textAreaGutter = color.RGBA{0x30, 0x30, 0x30, 0xff}
+
+func NewTextArea(size types.Size, color color.Color) *TextArea {
+	return &TextArea{
+		size:    size,
+		color:   color,
+		buffer:  textedit.New(""),
+		history: textedit.NewHistory(historyLimit),
+		texts:   []types.TextInfo{},
+	}
+}
+
+// Value returns the text typed into the area.
+func (a *TextArea) Value() string {
+	return a.buffer.String()
+}
+
+// SetValue replaces the text without calling the change handler and clears the history.
+func (a *TextArea) SetValue(str string) *TextArea {
+	a.buffer.Set(str)
+	a.history = textedit.NewHistory(historyLimit)
+	a.top = 0
+	return a
+}
+
+// SetLineNumbers shows or hides the line number gutter.
+func (a *TextArea) SetLineNumbers(show bool) *TextArea {
+	a.lineNumbers = show
+	return a
+}
+
+func (a *TextArea) OnChange(handler TextAreaHandler) *TextArea {
+	a.onChange = handler
+	return a
+}
+
+func (a *TextArea) Focused() bool {
+	return a.focused
+}
+
+func (a *TextArea) Focus() {
+	a.focused = true
+	a.ticks = 0
+}
+
+func (a *TextArea) Blur() {
+	a.focused = false
+	a.dragging = false
+}
+
+// Undo reverts the last edit and reports whether there was one.
+func (a *TextArea) Undo() bool {
+	return a.history.Undo(a.buffer)
+}
+
+// Redo reapplies the last undone edit and reports whether there was one.
+func (a *TextArea) Redo() bool {
+	return a.history.Redo(a.buffer)
+}
+
+// gutter is the width of the line number gutter.
+func (a *TextArea) gutter() int {
+	if !a.lineNumbers {
+		return 0
+	}
+	return util.TextWidth(strconv.Itoa(a.wrapping().numbers)) + textAreaPadding*2
+}
+
+// content is the area where text is drawn, relative to the craft.
+func (a *TextArea) content() (types.Position, types.Size) {
+	gutter := a.gutter()
+	return types.Position{X: gutter + textAreaPadding, Y: textAreaPadding},
+		types.Size{X: a.size.X - gutter - textAreaPadding*2, Y: a.size.Y - textAreaPadding*2}
+}
+
+func (a *TextArea) lines() []textedit.Line {
+	_, size := a.content()
+	w := a.wrapping()
+	if w.lines == nil || w.width != size.X {
+		w.width, w.lines = size.X, textedit.Wrap(w.text, size.X, util.TextWidth)
+	}
+	return w.lines
+}
+
+// wrapping returns the wrapped text, forgotten if the text or the face changed.
+// The buffer never edits its runes in place, so the same slice is the same text.
+func (a *TextArea) wrapping() *wrapping {
+	w, runes := &a.wrapped, a.buffer.Runes()
+	same := len(w.text) == len(runes) && (len(runes) == 0 || &w.text[0] == &runes[0])
+	if w.text == nil || !same || w.face != util.Face {
+		*w = wrapping{text: runes, face: util.Face, numbers: 1}
+		for _, r := range runes {
+			if r == '\n' {
+				w.numbers++
+			}
+		}
+	}
+	return w
+}
+
+// rows is the number of visible lines.
+func (a *TextArea) rows() int {
+	_, size := a.content()
+	return max(1, size.Y/util.LineHeight())
+}
+
+func (a *TextArea) lineText(l textedit.Line) string {
+	return string(a.buffer.Runes()[l.Start:l.End])
+}
+
+func (a *TextArea) Image() *ebiten.Image {
+	image := ebiten.NewImage(a.size.X, a.size.Y)
+	image.Fill(textInputBackground)
+
+	origin, _ := a.content()
+	lines := a.lines()
+	start, end := a.buffer.Selection()
+	caret := textedit.LineAt(lines, a.buffer.Caret())
+
+	if gutter := a.gutter(); gutter > 0 {
+		vector.DrawFilledRect(image, 0, 0, float32(gutter), float32(a.size.Y), textAreaGutter, false)
+	}
+
+	for n := a.top; n < min(len(lines), a.top+a.rows()); n++ {
+		l := lines[n]
+		y := origin.Y + (n-a.top)*util.LineHeight()
+
+		if a.lineNumbers && (n == 0 || lines[n-1].Number != l.Number) {
+			util.DrawTextAt(image, fmt.Sprint(l.Number+1), textPlaceholder, types.Position{X: textAreaPadding, Y: y})
+		}
+
+		if a.focused && start < l.End+1 && l.Start < end {
+			s, e := max(start, l.Start), min(end, l.End)
+			x0 := util.TextWidth(string(a.buffer.Runes()[l.Start:s]))
+			x1 := util.TextWidth(string(a.buffer.Runes()[l.Start:e]))
+			if end > l.End {
+				x1 += util.TextWidth(" ")
+			}
+			vector.DrawFilledRect(image,
+				float32(origin.X+x0), float32(y),
+				float32(x1-x0), float32(util.LineHeight()),
+				textSelection, false)
+		}
+
+		util.DrawTextAt(image, a.lineText(l), a.color, types.Position{X: origin.X, Y: y})
+
+		if a.focused && n == caret && (a.ticks/caretBlink)%2 == 0 {
+			x := origin.X + util.TextWidth(string(a.buffer.Runes()[l.Start:a.buffer.Caret()]))
+			vector.DrawFilledRect(image, float32(x), float32(y), 1, float32(util.LineHeight()), a.color, false)
+		}
+	}
+
+	vector.StrokeRect(image, 0.5, 0.5, float32(a.size.X)-1, float32(a.size.Y)-1, 1, a.border(), false)
+
+	util.DrawTexts(image, a.texts, types.LeftToRight)
+
+	return image
+}
+
+func (a *TextArea) border() color.Color {
+	if a.focused {
+		return textInputFocus
+	}
+	return textInputBorder
+}
+
+func (a *TextArea) Size() types.Size {
+	return a.size
+}
+
+func (a *TextArea) AddText(str string, color color.Color) Self {
+	a.texts = append(a.texts, types.TextInfo{Str: str, Color: color})
+	return a
+}
+
+func (a *TextArea) SetText(str string, color color.Color) Self {
+	a.texts = []types.TextInfo{{Str: str, Color: color}}
+	return a
+}
+
+func (a *TextArea) ClearText() Self {
+	a.texts = []types.TextInfo{}
+	return a
+}
+
+func (a *TextArea) Const() *Image {
+	return &Image{a.Image(), []types.TextInfo{}, types.LeftToRight}
+}
+
+func (a *TextArea) Update(p types.Position) error {
+	a.ticks++
+	return nil
+}
+
+func (a *TextArea) Focusable() bool {
+	return true
+}
+
+func (a *TextArea) CursorShape() ebiten.CursorShapeType {
+	return ebiten.CursorShapeText
+}
+
+func (a *TextArea) HandleEvent(e *event.Event) error {
+	switch e.Type {
+	case event.Focus:
+		a.Focus()
+	case event.Blur:
+		a.Blur()
+	case event.PointerDown:
+		if e.Button != ebiten.MouseButtonLeft {
+			return nil
+		}
+		a.Focus()
+		a.dragging = true
+		a.buffer.MoveTo(a.indexAt(e.Local()), e.Has(event.Shift))
+		e.StopPropagation()
+	case event.PointerMove:
+		if a.dragging {
+			a.buffer.MoveTo(a.indexAt(e.Local()), true)
+			a.scrollToCaret()
+		}
+	case event.PointerUp:
+		a.dragging = false
+	case event.Wheel:
+		a.wheel -= e.WheelY
+		lines := int(a.wheel)
+		a.wheel -= float64(lines)
+		a.scroll(lines)
+		e.StopPropagation()
+	case event.Text:
+		if !a.focused {
+			return nil
+		}
+		e.StopPropagation()
+		var chars []rune
+		for _, r := range e.Chars {
+			if unicode.IsPrint(r) {
+				chars = append(chars, r)
+			}
+		}
+		return a.edit(func() bool { return a.buffer.Insert(string(chars), 0) })
+	case event.KeyDown:
+		if !a.focused {
+			return nil
+		}
+		return a.handleKey(e)
+	}
+	return nil
+}
+
+func (a *TextArea) handleKey(e *event.Event) error {
+	ctrl := e.Has(event.Control) || e.Has(event.Meta)
+	shift := e.Has(event.Shift)
+
+	changed := false
+	switch {
+	case e.Key == ebiten.KeyLeft && ctrl:
+		a.buffer.WordLeft(shift)
+	case e.Key == ebiten.KeyLeft:
+		a.buffer.Left(shift)
+	case e.Key == ebiten.KeyRight && ctrl:
+		a.buffer.WordRight(shift)
+	case e.Key == ebiten.KeyRight:
+		a.buffer.Right(shift)
+	case e.Key == ebiten.KeyUp:
+		a.moveLine(-1, shift)
+	case e.Key == ebiten.KeyDown:
+		a.moveLine(1, shift)
+	case e.Key == ebiten.KeyPageUp:
+		a.moveLine(-a.rows(), shift)
+	case e.Key == ebiten.KeyPageDown:
+		a.moveLine(a.rows(), shift)
+	case e.Key == ebiten.KeyHome && ctrl:
+		a.buffer.Home(shift)
+	case e.Key == ebiten.KeyHome:
+		a.lineHome(shift)
+	case e.Key == ebiten.KeyEnd && ctrl:
+		a.buffer.End(shift)
+	case e.Key == ebiten.KeyEnd:
+		a.lineEnd(shift)
+	case e.Key == ebiten.KeyEnter:
+		e.StopPropagation()
+		return a.edit(func() bool { return a.buffer.Insert("\n", 0) })
+	case e.Key == ebiten.KeyBackspace:
+		e.StopPropagation()
+		return a.edit(a.buffer.Backspace)
+	case e.Key == ebiten.KeyDelete:
+		e.StopPropagation()
+		return a.edit(a.buffer.Delete)
+	case e.Key == ebiten.KeyA && ctrl && !e.Repeat:
+		a.buffer.SelectAll()
+	case e.Key == ebiten.KeyC && ctrl && !e.Repeat:
+		a.copy()
+	case e.Key == ebiten.KeyX && ctrl && !e.Repeat:
+		a.copy()
+		e.StopPropagation()
+		return a.edit(a.buffer.DeleteSelection)
+	case e.Key == ebiten.KeyV && ctrl:
+		e.StopPropagation()
+		return a.edit(func() bool { return a.buffer.Insert(clipboard, 0) })
+	case e.Key == ebiten.KeyZ && ctrl && shift, e.Key == ebiten.KeyY && ctrl:
+		changed = a.Redo()
+	case e.Key == ebiten.KeyZ && ctrl:
+		changed = a.Undo()
+	default:
+		// Typed keys are text here, not shortcuts.
+		if e.Typed() {
+			e.StopPropagation()
+		}
+		return nil
+	}
+
+	e.StopPropagation()
+	a.scrollToCaret()
+	if changed && a.onChange != nil {
+		return a.onChange(a)
+	}
+	return nil
+}
+
+// edit applies f, saving the previous state to the history if the text changed.
+func (a *TextArea) edit(f func() bool) error {
+	a.ticks = 0
+	before := *a.buffer
+	changed := f()
+	if changed {
+		a.history.Save(&before)
+	}
+	a.scrollToCaret()
+	if changed && a.onChange != nil {
+		return a.onChange(a)
+	}
+	return nil
+}
+
+// indexAt returns the rune index nearest to the local position p.
+func (a *TextArea) indexAt(p types.Position) int {
+	origin, _ := a.content()
+	lines := a.lines()
+	n := max(0, min(len(lines)-1, a.top+(p.Y-origin.Y)/util.LineHeight()))
+	return lines[n].Start + util.TextIndex(a.lineText(lines[n]), p.X-origin.X)
+}
+
+// moveLine moves the caret by delta visual lines, keeping its x coordinate.
+func (a *TextArea) moveLine(delta int, extend bool) {
+	lines := a.lines()
+	n := textedit.LineAt(lines, a.buffer.Caret())
+	x := util.TextWidth(string(a.buffer.Runes()[lines[n].Start:a.buffer.Caret()]))
+
+	target := n + delta
+	switch {
+	case target < 0:
+		a.buffer.Home(extend)
+	case target >= len(lines):
+		a.buffer.End(extend)
+	default:
+		l := lines[target]
+		a.buffer.MoveTo(min(l.Start+util.TextIndex(a.lineText(l), x), a.lineEndOf(lines, target)), extend)
+	}
+}
+
+func (a *TextArea) lineHome(extend bool) {
+	lines := a.lines()
+	a.buffer.MoveTo(lines[textedit.LineAt(lines, a.buffer.Caret())].Start, extend)
+}
+
+func (a *TextArea) lineEnd(extend bool) {
+	lines := a.lines()
+	a.buffer.MoveTo(a.lineEndOf(lines, textedit.LineAt(lines, a.buffer.Caret())), extend)
+}
+
+// lineEndOf is the last caret position of line n that is still drawn on l.
+// The caret after a soft wrap belongs to the next line, so it stops one rune before.
+func (a *TextArea) lineEndOf(lines []textedit.Line, n int) int {
+	l := lines[n]
+	if n+1 < len(lines) && lines[n+1].Number == l.Number && l.End > l.Start {
+		return l.End - 1
+	}
+	return l.End
+}
+
+func (a *TextArea) copy() {
+	if a.buffer.HasSelection() {
+		clipboard = a.buffer.Selected()
+	}
+}
+
+func (a *TextArea) scroll(delta int) {
+	a.top = max(0, min(a.top+delta, len(a.lines())-a.rows()))
+}
+
+// scrollToCaret keeps the caret line inside the visible area.
+func (a *TextArea) scrollToCaret() {
+	n := textedit.LineAt(a.lines(), a.buffer.Caret())
+	if n < a.top {
+		a.top = n
+	}
+	if n >= a.top+a.rows() {
+		a.top = n - a.rows() + 1
+	}
+}
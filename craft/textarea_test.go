@@ -0,0 +1,145 @@
+package craft
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+)
+
+var _ Craft = NewTextArea(types.Size{X: 100, Y: 100}, color.White)
+
+func TestTextAreaMoveLine(t *testing.T) {
+	// 64px of content fits 10 runes per line and 3 lines.
+	area := NewTextArea(types.Size{X: 64, Y: 52}, color.White).SetValue("hello world\nab")
+
+	tests := []struct {
+		caret int
+		delta int
+		want  int
+	}{
+		{1, 1, 7},
+		{7, -1, 1},
+		{10, 1, 14},
+		{14, -1, 8},
+		{3, -1, 0},
+		{13, 1, 14},
+		{4, 1, 10},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			area.buffer.MoveTo(tt.caret, false)
+			area.moveLine(tt.delta, false)
+			if area.buffer.Caret() != tt.want {
+				t.Errorf("moveLine should move the caret to %d, but got %d", tt.want, area.buffer.Caret())
+			}
+		})
+	}
+}
+
+func TestTextAreaLineEnd(t *testing.T) {
+	area := NewTextArea(types.Size{X: 64, Y: 52}, color.White).SetValue("hello world\nab")
+
+	area.buffer.MoveTo(0, false)
+	area.lineEnd(false)
+	if area.buffer.Caret() != 5 {
+		t.Errorf("lineEnd should stop before a soft wrap, but got %d", area.buffer.Caret())
+	}
+
+	area.buffer.MoveTo(6, false)
+	area.lineEnd(false)
+	if area.buffer.Caret() != 11 {
+		t.Errorf("lineEnd should stop before a newline, but got %d", area.buffer.Caret())
+	}
+}
+
+func TestTextAreaScrollToCaret(t *testing.T) {
+	area := NewTextArea(types.Size{X: 64, Y: 52}, color.White).SetValue("1\n2\n3\n4\n5")
+
+	area.scrollToCaret()
+	if area.top != 2 {
+		t.Errorf("scrollToCaret should scroll to the caret, but got %d", area.top)
+	}
+
+	area.buffer.Home(false)
+	area.scrollToCaret()
+	if area.top != 0 {
+		t.Errorf("scrollToCaret should scroll back, but got %d", area.top)
+	}
+
+	area.scroll(10)
+	if area.top != 2 {
+		t.Errorf("scroll should stop at the last line, but got %d", area.top)
+	}
+}
+
+func TestTextAreaWheel(t *testing.T) {
+	area := NewTextArea(types.Size{X: 64, Y: 52}, color.White).SetValue("1\n2\n3\n4\n5\n6\n7\n8")
+
+	tests := []struct {
+		wheel float64
+		top   int
+	}{
+		{-0.4, 0},
+		{-0.4, 0},
+		{-0.4, 1},
+		{-1.5, 2},
+		{1, 2},
+		{0.5, 2},
+		{0.5, 1},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			area.HandleEvent(&event.Event{Type: event.Wheel, WheelY: tt.wheel})
+			if area.top != tt.top {
+				t.Errorf("the wheel should scroll to %d, but got %d", tt.top, area.top)
+			}
+		})
+	}
+}
+
+func TestTextAreaWrapCache(t *testing.T) {
+	area := NewTextArea(types.Size{X: 64, Y: 52}, color.White).SetValue("hello world\nab")
+
+	lines := area.lines()
+	if again := area.lines(); &again[0] != &lines[0] {
+		t.Errorf("lines should be kept while the text does not change")
+	}
+
+	area.buffer.Insert("\ncd", 0)
+	if got := area.lines(); len(got) != 4 || area.wrapping().numbers != 3 {
+		t.Errorf("lines should be wrapped again after an edit, but got %v", got)
+	}
+}
+
+func TestTextAreaGutter(t *testing.T) {
+	area := NewTextArea(types.Size{X: 100, Y: 100}, color.White).SetValue("1\n2\n3\n4\n5\n6\n7\n8\n9\n10")
+
+	if g := area.gutter(); g != 0 {
+		t.Errorf("gutter should be hidden, but got %d", g)
+	}
+
+	area.SetLineNumbers(true)
+	if g := area.gutter(); g != 16 {
+		t.Errorf("gutter should fit two digits, but got %d", g)
+	}
+}
+
+func TestTextAreaUndo(t *testing.T) {
+	area := NewTextArea(types.Size{X: 100, Y: 100}, color.White)
+
+	before := *area.buffer
+	area.buffer.Insert("abc", 0)
+	area.history.Save(&before)
+
+	if !area.Undo() || area.Value() != "" {
+		t.Errorf("Undo should revert the edit, but got %q", area.Value())
+	}
+	if !area.Redo() || area.Value() != "abc" {
+		t.Errorf("Redo should reapply the edit, but got %q", area.Value())
+	}
+}
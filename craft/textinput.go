@@ -0,0 +1,373 @@
+package craft
+
+import (
+	"image/color"
+	"strings"
+	"unicode"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/internal/textedit"
+	"github.com/a-skua/etk/craft/internal/util"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// clipboard is shared by the text crafts for copy, cut and paste within the app.
+var clipboard string
+
+type TextInputHandler func(*TextInput) error
+
+// TextInput Craft
+//
+// TextInput is a single-line text field.
+// It is focused by clicking inside it and receives Text events.
+// While it is focused, the text an IME is composing is shown underlined at the caret
+// until it is committed, except in a password input.
+// Caret positions assume left-to-right text.
+//
+// ```
+// +---------------+
+// | text|         |
+// +---------------+
+// ```
+type TextInput struct {
+	width       int
+	color       color.Color
+	buffer      *textedit.Buffer
+	placeholder string
+	maxLength   int
+	password    bool
+	focused     bool
+	dragging    bool
+	scroll      int
+	ticks       int
+	composition string
+	onChange    TextInputHandler
+	onSubmit    TextInputHandler
+	texts       []types.TextInfo
+}
+
+const (
+	textInputPadding = 2
+	passwordMask     = "*"
+	caretBlink       = 30
+)
+
+var (
+	textInputBackground = color.RGBA{0x20, 0x20, 0x20, 0xff}
+	textInputBorder     = color.RGBA{0x80, 0x80, 0x80, 0xff}
+	textInputFocus      = color.RGBA{0x40, 0x80, 0xff, 0xff}
+	textSelection       = color.RGBA{0x40, 0x60, 0xa0, 0xff}
+	textPlaceholder     = color.RGBA{0x80, 0x80, 0x80, 0xff}
+)
+
+func NewTextInput(width int, color color.Color) *TextInput {
+	return &TextInput{
+		width:  width,
+		color:  color,
+		buffer: textedit.New(""),
+		texts:  []types.TextInfo{},
+	}
+}
+
+// Value returns the text typed into the input.
+func (t *TextInput) Value() string {
+	return t.buffer.String()
+}
+
+// SetValue replaces the text without calling the change handler.
+func (t *TextInput) SetValue(str string) *TextInput {
+	if t.maxLength > 0 {
+		str = string([]rune(str)[:min(len([]rune(str)), t.maxLength)])
+	}
+	t.buffer.Set(str)
+	return t
+}
+
+func (t *TextInput) SetPlaceholder(str string) *TextInput {
+	t.placeholder = str
+	return t
+}
+
+// SetMaxLength limits the number of runes. Zero means no limit.
+func (t *TextInput) SetMaxLength(n int) *TextInput {
+	t.maxLength = n
+	return t
+}
+
+// SetPassword masks the displayed text, and keeps it from being copied or cut.
+func (t *TextInput) SetPassword(password bool) *TextInput {
+	t.password = password
+	return t
+}
+
+func (t *TextInput) OnChange(handler TextInputHandler) *TextInput {
+	t.onChange = handler
+	return t
+}
+
+func (t *TextInput) OnSubmit(handler TextInputHandler) *TextInput {
+	t.onSubmit = handler
+	return t
+}
+
+func (t *TextInput) Focused() bool {
+	return t.focused
+}
+
+func (t *TextInput) Focus() {
+	t.focused = true
+	t.ticks = 0
+}
+
+func (t *TextInput) Blur() {
+	t.focused = false
+	t.dragging = false
+	t.composition = ""
+}
+
+// display is the text as it is drawn.
+func (t *TextInput) display() string {
+	if t.password {
+		return strings.Repeat(passwordMask, t.buffer.Len())
+	}
+	return t.buffer.String()
+}
+
+// caretX is the x coordinate of the rune index i in the displayed text.
+func (t *TextInput) caretX(i int) int {
+	return util.TextWidth(string([]rune(t.display())[:i]))
+}
+
+func (t *TextInput) Image() *ebiten.Image {
+	size := t.Size()
+
+	image := ebiten.NewImage(size.X, size.Y)
+	image.Fill(textInputBackground)
+
+	border := textInputBorder
+	if t.focused {
+		border = textInputFocus
+	}
+	vector.StrokeRect(image, 0.5, 0.5, float32(size.X)-1, float32(size.Y)-1, 1, border, false)
+
+	origin := types.Position{X: textInputPadding - t.scroll, Y: textInputPadding}
+	if t.buffer.Len() == 0 && t.placeholder != "" {
+		util.DrawTextAt(image, t.placeholder, textPlaceholder, origin)
+	}
+
+	if start, end := t.buffer.Selection(); t.focused && start != end {
+		x0, x1 := t.caretX(start), t.caretX(end)
+		vector.DrawFilledRect(image,
+			float32(origin.X+x0), float32(origin.Y),
+			float32(x1-x0), float32(util.LineHeight()),
+			textSelection, false)
+	}
+
+	str, caret := t.display(), t.caretX(t.buffer.Caret())
+	if t.composition != "" {
+		runes := []rune(str)
+		str = string(runes[:t.buffer.Caret()]) + t.composition + string(runes[t.buffer.Caret():])
+		y := float32(origin.Y+util.LineHeight()) - 0.5
+		width := util.TextWidth(t.composition)
+		vector.StrokeLine(image, float32(origin.X+caret), y, float32(origin.X+caret+width), y, 1, t.color, false)
+		caret += width
+	}
+	util.DrawTextAt(image, str, t.color, origin)
+
+	if t.focused && (t.ticks/caretBlink)%2 == 0 {
+		x := origin.X + caret
+		vector.DrawFilledRect(image, float32(x), float32(origin.Y), 1, float32(util.LineHeight()), t.color, false)
+	}
+
+	util.DrawTexts(image, t.texts, types.LeftToRight)
+
+	return image
+}
+
+func (t *TextInput) Size() types.Size {
+	return types.Size{X: t.width, Y: util.LineHeight() + textInputPadding*2}
+}
+
+func (t *TextInput) AddText(str string, color color.Color) Self {
+	t.texts = append(t.texts, types.TextInfo{Str: str, Color: color})
+	return t
+}
+
+func (t *TextInput) SetText(str string, color color.Color) Self {
+	t.texts = []types.TextInfo{{Str: str, Color: color}}
+	return t
+}
+
+func (t *TextInput) ClearText() Self {
+	t.texts = []types.TextInfo{}
+	return t
+}
+
+func (t *TextInput) Const() *Image {
+	return &Image{t.Image(), []types.TextInfo{}, types.LeftToRight}
+}
+
+// Update asks for the IME while the input is focused, with its window below the caret.
+func (t *TextInput) Update(p types.Position) error {
+	t.ticks++
+	if t.focused && !t.password {
+		x := textInputPadding - t.scroll + t.caretX(t.buffer.Caret())
+		input.ComposeAt(p.Add(types.Position{X: x, Y: t.Size().Y}))
+	}
+	return nil
+}
+
+func (t *TextInput) Focusable() bool {
+	return true
+}
+
+func (t *TextInput) CursorShape() ebiten.CursorShapeType {
+	return ebiten.CursorShapeText
+}
+
+func (t *TextInput) HandleEvent(e *event.Event) error {
+	switch e.Type {
+	case event.Focus:
+		t.Focus()
+	case event.Blur:
+		t.Blur()
+	case event.PointerDown:
+		if e.Button != ebiten.MouseButtonLeft {
+			return nil
+		}
+		t.Focus()
+		t.dragging = true
+		t.buffer.MoveTo(t.indexAt(e.Local()), e.Has(event.Shift))
+		e.StopPropagation()
+	case event.PointerMove:
+		if t.dragging {
+			t.buffer.MoveTo(t.indexAt(e.Local()), true)
+			t.scrollToCaret()
+		}
+	case event.PointerUp:
+		t.dragging = false
+	case event.Text:
+		if !t.focused {
+			return nil
+		}
+		e.StopPropagation()
+		var chars []rune
+		for _, r := range e.Chars {
+			if unicode.IsPrint(r) {
+				chars = append(chars, r)
+			}
+		}
+		return t.edit(func() bool { return t.buffer.Insert(string(chars), t.maxLength) })
+	case event.Compose:
+		if !t.focused || t.password {
+			return nil
+		}
+		e.StopPropagation()
+		t.composition = string(e.Chars)
+		t.scrollToCaret()
+	case event.KeyDown:
+		if !t.focused {
+			return nil
+		}
+		return t.handleKey(e)
+	}
+	return nil
+}
+
+func (t *TextInput) handleKey(e *event.Event) error {
+	ctrl := e.Has(event.Control) || e.Has(event.Meta)
+	shift := e.Has(event.Shift)
+
+	switch {
+	case e.Key == ebiten.KeyLeft && ctrl:
+		t.buffer.WordLeft(shift)
+	case e.Key == ebiten.KeyLeft:
+		t.buffer.Left(shift)
+	case e.Key == ebiten.KeyRight && ctrl:
+		t.buffer.WordRight(shift)
+	case e.Key == ebiten.KeyRight:
+		t.buffer.Right(shift)
+	case e.Key == ebiten.KeyHome:
+		t.buffer.Home(shift)
+	case e.Key == ebiten.KeyEnd:
+		t.buffer.End(shift)
+	case e.Key == ebiten.KeyBackspace:
+		return t.stop(e, t.edit(t.buffer.Backspace))
+	case e.Key == ebiten.KeyDelete:
+		return t.stop(e, t.edit(t.buffer.Delete))
+	case e.Key == ebiten.KeyA && ctrl && !e.Repeat:
+		t.buffer.SelectAll()
+	case e.Key == ebiten.KeyC && ctrl && !e.Repeat:
+		t.copy()
+	case e.Key == ebiten.KeyX && ctrl && !e.Repeat:
+		// A password cannot be copied, so it is not cut either.
+		if t.password {
+			break
+		}
+		t.copy()
+		return t.stop(e, t.edit(t.buffer.DeleteSelection))
+	case e.Key == ebiten.KeyV && ctrl:
+		return t.stop(e, t.edit(func() bool { return t.buffer.Insert(clipboard, t.maxLength) }))
+	case e.Key == ebiten.KeyEnter && !e.Repeat:
+		e.StopPropagation()
+		if t.onSubmit != nil {
+			return t.onSubmit(t)
+		}
+		return nil
+	default:
+		// Typed keys come back as Text, so they are not shortcuts of the crafts around.
+		if e.Typed() {
+			e.StopPropagation()
+		}
+		return nil
+	}
+
+	e.StopPropagation()
+	t.scrollToCaret()
+	return nil
+}
+
+// stop stops the propagation of a key the input handled.
+func (t *TextInput) stop(e *event.Event, err error) error {
+	e.StopPropagation()
+	return err
+}
+
+// edit applies f and calls the change handler if the text changed.
+func (t *TextInput) edit(f func() bool) error {
+	t.ticks = 0
+	changed := f()
+	t.scrollToCaret()
+	if changed && t.onChange != nil {
+		return t.onChange(t)
+	}
+	return nil
+}
+
+// indexAt returns the rune index nearest to the local position p.
+func (t *TextInput) indexAt(p types.Position) int {
+	return util.TextIndex(t.display(), p.X-textInputPadding+t.scroll)
+}
+
+// copy puts the selection into the clipboard. The masked text of a password is never copied.
+func (t *TextInput) copy() {
+	if t.password || !t.buffer.HasSelection() {
+		return
+	}
+	clipboard = t.buffer.Selected()
+}
+
+// scrollToCaret keeps the caret inside the visible area.
+func (t *TextInput) scrollToCaret() {
+	visible := t.width - textInputPadding*2
+	x := t.caretX(t.buffer.Caret()) + util.TextWidth(t.composition)
+	if x-t.scroll > visible {
+		t.scroll = x - visible
+	}
+	if x-t.scroll < 0 {
+		t.scroll = x
+	}
+}
@@ -0,0 +1,169 @@
+package craft
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/a-skua/etk/craft/event"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ Craft = NewTextInput(100, color.White)
+
+func TestTextInputSize(t *testing.T) {
+	input := NewTextInput(100, color.White)
+
+	want := types.Size{X: 100, Y: 20}
+	if size := input.Size(); size != want {
+		t.Errorf("Size should return %v, but got %v", want, size)
+	}
+}
+
+func TestTextInputSetValue(t *testing.T) {
+	tests := []struct {
+		maxLength int
+		value     string
+		want      string
+	}{
+		{0, "hello", "hello"},
+		{3, "hello", "hel"},
+		{3, "あいうえお", "あいう"},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			input := NewTextInput(100, color.White).SetMaxLength(tt.maxLength).SetValue(tt.value)
+			if input.Value() != tt.want {
+				t.Errorf("Value should return %q, but got %q", tt.want, input.Value())
+			}
+		})
+	}
+}
+
+func TestTextInputPassword(t *testing.T) {
+	input := NewTextInput(100, color.White).SetPassword(true).SetValue("secret")
+
+	if d := input.display(); d != "******" {
+		t.Errorf("display should mask the value, but got %q", d)
+	}
+	if input.Value() != "secret" {
+		t.Errorf("Value should return the raw value, but got %q", input.Value())
+	}
+
+	clipboard = ""
+	input.buffer.SelectAll()
+	input.copy()
+	if clipboard != "" {
+		t.Errorf("copy should not copy a password, but got %q", clipboard)
+	}
+
+	input.Focus()
+	input.HandleEvent(&event.Event{Type: event.KeyDown, Key: ebiten.KeyX, Modifiers: event.Control})
+	if input.Value() != "secret" || clipboard != "" {
+		t.Errorf("Ctrl+X should not cut a password, but got %q", input.Value())
+	}
+}
+
+func TestTextInputCopy(t *testing.T) {
+	input := NewTextInput(100, color.White).SetValue("hello world")
+	input.buffer.MoveTo(0, false)
+	input.buffer.WordRight(true)
+
+	clipboard = ""
+	input.copy()
+	if clipboard != "hello" {
+		t.Errorf("copy should copy the selection, but got %q", clipboard)
+	}
+}
+
+func TestTextInputScrollToCaret(t *testing.T) {
+	input := NewTextInput(34, color.White).SetValue("abcdefghij")
+
+	input.scrollToCaret()
+	if input.scroll != 30 {
+		t.Errorf("scroll should follow the caret, but got %d", input.scroll)
+	}
+
+	input.buffer.Home(false)
+	input.scrollToCaret()
+	if input.scroll != 0 {
+		t.Errorf("scroll should return to the start, but got %d", input.scroll)
+	}
+}
+
+func TestTextInputHandleEvent(t *testing.T) {
+	tests := []struct {
+		focused bool
+		events  []event.Event
+		want    string
+	}{
+		{false, []event.Event{{Type: event.Text, Chars: []rune("ab")}}, ""},
+		{true, []event.Event{{Type: event.Text, Chars: []rune("a\tb")}}, "ab"},
+		{true, []event.Event{
+			{Type: event.Text, Chars: []rune("abc")},
+			{Type: event.KeyDown, Key: ebiten.KeyBackspace},
+		}, "ab"},
+		{true, []event.Event{
+			{Type: event.Text, Chars: []rune("abc")},
+			{Type: event.KeyDown, Key: ebiten.KeyA, Modifiers: event.Control},
+			{Type: event.KeyDown, Key: ebiten.KeyDelete},
+		}, ""},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			input := NewTextInput(100, color.White)
+			if tt.focused {
+				input.Focus()
+			}
+			for _, e := range tt.events {
+				if err := input.HandleEvent(&e); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := input.Value(); got != tt.want {
+				t.Errorf("Value should return %q, but got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTextInputCompose(t *testing.T) {
+	text := NewTextInput(100, color.White)
+	in := input.New()
+	d := event.NewDispatcher()
+	d.Dispatch(text, in)
+	d.SetFocus(text)
+
+	tests := []struct {
+		state       input.State
+		value       string
+		composition string
+	}{
+		{input.State{Composition: "に"}, "", "に"},
+		{input.State{Composition: "にほん"}, "", "にほん"},
+		{input.State{Chars: []rune("日本")}, "日本", ""},
+		{input.State{Composition: "ご"}, "日本", "ご"},
+		{input.State{}, "日本", ""},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			in.Update(tt.state)
+			d.Dispatch(text, in)
+			if text.Value() != tt.value || text.composition != tt.composition {
+				t.Errorf("Value and the composition should be %q and %q, but got %q and %q", tt.value, tt.composition, text.Value(), text.composition)
+			}
+		})
+	}
+
+	text.SetPassword(true)
+	in.Update(input.State{Composition: "に"})
+	d.Dispatch(text, in)
+	if text.composition != "" {
+		t.Errorf("a password should not show the composition, but got %q", text.composition)
+	}
+}
@@ -10,6 +10,22 @@ type TextInfo struct {
 	Color color.Color
 }
 
+// Direction of text and horizontal layout
+type Direction int
+
+const (
+	LeftToRight Direction = iota
+	RightToLeft
+)
+
+// Orientation of a control such as a slider
+type Orientation int
+
+const (
+	Horizontal Orientation = iota
+	Vertical
+)
+
 // Size
 type Size image.Point
 
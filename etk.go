@@ -4,12 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"image/color"
+	"io"
 
 	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/event"
 	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 type Scene interface {
@@ -19,20 +22,44 @@ type Scene interface {
 	Previous() bool
 }
 
+// Root is a scene with a craft tree.
+// Game dispatches the input of each tick to the tree as events before updating the scene.
+type Root interface {
+	Root() craft.Craft
+}
+
+// Actor is a scene reading named actions such as "confirm" or "jump".
+// Game gives each Actor its input and action map.
+type Actor interface {
+	SetActions(in *input.Input, actions *input.Map)
+}
+
 type DefaultScene struct {
 	Craft craft.Craft
+
+	input   *input.Input
+	actions *input.Map
 }
 
 func (DefaultScene) Init() {}
 
-func (DefaultScene) Next() bool {
-	return inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+func (s *DefaultScene) SetActions(in *input.Input, actions *input.Map) {
+	s.input, s.actions = in, actions
+}
+
+// Next reports whether the confirm action was just pressed.
+func (s *DefaultScene) Next() bool {
+	return s.actions != nil && s.actions.IsJustPressed(s.input, input.ActionConfirm)
 }
 
 func (DefaultScene) Previous() bool {
 	return false
 }
 
+func (s *DefaultScene) Root() craft.Craft {
+	return s.Craft
+}
+
 func (s *DefaultScene) Update() error {
 	return s.Craft.Update(types.Position{})
 }
@@ -72,22 +99,39 @@ func (s *scene) Current() Scene {
 }
 
 type Game struct {
-	scene   scene
-	width   int
-	height  int
-	options []interface {
+	scene      scene
+	width      int
+	height     int
+	source     input.Source
+	recorder   *input.Recorder
+	input      *input.Input
+	actions    *input.Map
+	dispatcher *event.Dispatcher
+	cursor     ebiten.CursorShapeType
+	options    []interface {
 		Update() error
 		Draw(screen *ebiten.Image)
 	}
 }
 
 func New(width, height int, scene Scene, scenes ...Scene) *Game {
-	g := &Game{}
+	g := &Game{
+		source:     input.Live,
+		input:      input.New(),
+		actions:    input.DefaultMap(),
+		dispatcher: event.NewDispatcher(),
+	}
+	g.dispatcher.SetActions(g.actions)
 	g.width = width
 	g.height = height
 	g.scene.list = make([]Scene, 0, len(scenes)+1)
 	g.scene.list = append(g.scene.list, scene)
 	g.scene.list = append(g.scene.list, scenes...)
+	for _, s := range g.scene.list {
+		if a, ok := s.(Actor); ok {
+			a.SetActions(g.input, g.actions)
+		}
+	}
 	g.scene.Current().Init()
 	return g
 }
@@ -101,7 +145,36 @@ func (g *Game) Update() error {
 	// 	g.scene.Current().Init()
 	// }
 
-	err := g.scene.Current().Update()
+	state, err := g.source.Read()
+	if errors.Is(err, io.EOF) {
+		// The replay is over; the live input takes over.
+		g.source = input.Live
+		state, err = g.source.Read()
+	}
+	g.input.Update(state)
+
+	if r, ok := g.scene.Current().(Root); ok && r.Root() != nil {
+		err = errors.Join(err, g.dispatcher.Dispatch(r.Root(), g.input))
+	}
+	if shape := g.dispatcher.CursorShape(); shape != g.cursor {
+		g.cursor = shape
+		ebiten.SetCursorShape(shape)
+	}
+
+	// Going back with Escape, B or whatever the back action is bound to, when no craft
+	// handled it, moves to the previous scene only if the scene agrees with Scene.Previous,
+	// and never past the first one. Scenes with text input should keep Previous false.
+	if g.dispatcher.Back() && g.scene.current > 0 && g.scene.Current().Previous() {
+		g.scene.prev()
+		g.scene.Current().Init()
+	}
+
+	err = errors.Join(err, g.scene.Current().Update())
+	for _, o := range g.dispatcher.Overlays() {
+		if u, ok := o.Target.(interface{ Update(types.Position) error }); ok {
+			err = errors.Join(err, u.Update(o.Position))
+		}
+	}
 	for _, option := range g.options {
 		err = errors.Join(option.Update())
 	}
@@ -110,32 +183,147 @@ func (g *Game) Update() error {
 
 func (g *Game) Draw(screen *ebiten.Image) {
 	g.scene.Current().Draw(screen)
+	g.drawOverlays(screen)
+	g.drawFocusRing(screen)
+	g.drawGhost(screen)
 	for _, option := range g.options {
 		option.Draw(screen)
 	}
 }
 
+// Dispatcher returns the dispatcher of the events, which also manages the focus.
+func (g *Game) Dispatcher() *event.Dispatcher {
+	return g.dispatcher
+}
+
+// ShowDialog shows d above the current scene until it is closed.
+// Dialogs can also be shown by crafts, with the dispatcher of an event.
+func (g *Game) ShowDialog(d *craft.Dialog) error {
+	return d.Show(g.dispatcher)
+}
+
+// SetSource replaces the source of the input, such as input.Live.
+func (g *Game) SetSource(source input.Source) *Game {
+	g.source = source
+	return g
+}
+
+// Record records the input of every tick to w until StopRecording.
+// Replaying it with the same scenes reproduces the session.
+func (g *Game) Record(w io.Writer) *Game {
+	g.recorder = input.NewRecorder(g.source, w)
+	g.source = g.recorder
+	return g
+}
+
+// StopRecording writes the last ticks recorded and stops recording.
+func (g *Game) StopRecording() error {
+	if g.recorder == nil {
+		return nil
+	}
+	err := g.recorder.Close()
+	g.source, g.recorder = input.Live, nil
+	return err
+}
+
+// Replay reads the input from a recording instead of ebiten.
+// Once it is over, the live input takes over.
+func (g *Game) Replay(r io.Reader) *Game {
+	g.source = input.NewPlayer(r)
+	return g
+}
+
+// Input returns the input of the current tick.
+func (g *Game) Input() *input.Input {
+	return g.input
+}
+
+// Actions returns the action map shared by the scenes and the dispatcher.
+// Rebinding an action takes effect from the next tick.
+func (g *Game) Actions() *input.Map {
+	return g.actions
+}
+
+// DimColor is drawn over the screen below a modal overlay, such as a dialog.
+var DimColor color.Color = color.RGBA{0x00, 0x00, 0x00, 0x80}
+
+// drawOverlays draws the overlays opened by crafts, such as popups, above the scene.
+func (g *Game) drawOverlays(screen *ebiten.Image) {
+	for _, o := range g.dispatcher.Overlays() {
+		if o.Modal {
+			size := screen.Bounds().Size()
+			vector.DrawFilledRect(screen, 0, 0, float32(size.X), float32(size.Y), DimColor, false)
+		}
+		c, ok := o.Target.(interface{ Image() *ebiten.Image })
+		if !ok {
+			continue
+		}
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(o.Position.X), float64(o.Position.Y))
+		screen.DrawImage(c.Image(), op)
+	}
+}
+
+// FocusRingColor is the color of the ring around the craft focused by the keyboard.
+var FocusRingColor color.Color = color.RGBA{0x40, 0x90, 0xff, 0xff}
+
+const focusRingWidth = 2
+
+func (g *Game) drawFocusRing(screen *ebiten.Image) {
+	if !g.dispatcher.FocusVisible() {
+		return
+	}
+	p, size, ok := g.dispatcher.FocusBounds()
+	if !ok {
+		return
+	}
+	vector.StrokeRect(
+		screen,
+		float32(p.X)-focusRingWidth/2, float32(p.Y)-focusRingWidth/2,
+		float32(size.X)+focusRingWidth, float32(size.Y)+focusRingWidth,
+		focusRingWidth, FocusRingColor, false,
+	)
+}
+
+// ghostAlpha is the opacity of the craft following the cursor while dragged.
+const ghostAlpha = 0.6
+
+func (g *Game) drawGhost(screen *ebiten.Image) {
+	drag := g.dispatcher.Dragging()
+	if drag == nil || drag.Ghost == nil {
+		return
+	}
+
+	p := drag.Position().Add(drag.Offset)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(p.X), float64(p.Y))
+	op.ColorScale.ScaleAlpha(ghostAlpha)
+	screen.DrawImage(drag.Ghost, op)
+}
+
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return g.scene.Current().Layout(g.width, g.height)
 }
 
-type debug struct{}
+type debug struct {
+	input *input.Input
+}
 
 func (d debug) Update() error {
 	return nil
 }
 
 func (d debug) Draw(screen *ebiten.Image) {
-	x, y := ebiten.CursorPosition()
+	p := d.input.Cursor()
 	ebitenutil.DebugPrint(screen, fmt.Sprintf(
 		"FPS: %0.2f\nTPS: %0.2f\nP: (%d, %d)\n",
 		ebiten.ActualFPS(),
 		ebiten.ActualTPS(),
-		x, y,
+		p.X, p.Y,
 	))
 }
 
 func (g *Game) Debug() *Game {
-	g.options = append(g.options, debug{})
+	g.options = append(g.options, debug{g.input})
 	return g
 }
@@ -1,7 +1,16 @@
 package etk
 
 import (
+	"bytes"
+	"fmt"
+	"image/color"
 	"testing"
+
+	"github.com/a-skua/etk/craft"
+	"github.com/a-skua/etk/craft/action"
+	"github.com/a-skua/etk/craft/types"
+	"github.com/a-skua/etk/input"
+	"github.com/hajimehoshi/ebiten/v2"
 )
 
 var _ Scene = &DefaultScene{}
@@ -72,3 +81,96 @@ func Test_scene_Current(t *testing.T) {
 		t.Errorf("Current() should return the second element of list")
 	}
 }
+
+// clicks is a scene counting the left button presses on its root.
+type clicks struct {
+	DefaultScene
+	count int
+}
+
+func newClicks() *clicks {
+	s := &clicks{}
+	s.Craft = action.NewMousePressed(
+		craft.NewFill(types.Size{X: 10, Y: 10}, color.White),
+		ebiten.MouseButtonLeft,
+		func(*craft.Fill) error { s.count++; return nil },
+	)
+	return s
+}
+
+func TestGameReplay(t *testing.T) {
+	states := []input.State{}
+	for i := range 20 {
+		s := input.State{Cursor: types.Position{X: i, Y: 5}}
+		if i%4 == 0 {
+			s.Buttons = []ebiten.MouseButton{ebiten.MouseButtonLeft}
+		}
+		states = append(states, s)
+	}
+
+	recorded := newClicks()
+	buf := &bytes.Buffer{}
+	g := New(10, 10, recorded).SetSource(input.SourceFunc(func() (input.State, error) {
+		s := states[0]
+		states = states[1:]
+		return s, nil
+	})).Record(buf)
+	for range 20 {
+		g.Update()
+	}
+	if err := g.StopRecording(); err != nil {
+		t.Fatalf("StopRecording should not return an error, but got %v", err)
+	}
+
+	replayed := newClicks()
+	g = New(10, 10, replayed).Replay(buf)
+	for range 20 {
+		g.Update()
+	}
+
+	if recorded.count != 3 || replayed.count != recorded.count {
+		t.Errorf("replay should press %d times as recorded, but got %d and %d", 3, recorded.count, replayed.count)
+	}
+}
+
+// backable is a scene leaving for the previous one on back when previous is set.
+type backable struct {
+	DefaultScene
+	previous bool
+}
+
+func (s *backable) Previous() bool {
+	return s.previous
+}
+
+func TestGameBack(t *testing.T) {
+	newScene := func(previous bool) *backable {
+		return &backable{DefaultScene{Craft: craft.NewFill(types.Size{X: 10, Y: 10}, color.White)}, previous}
+	}
+
+	tests := []struct {
+		current  int
+		previous bool
+		want     int
+	}{
+		{0, true, 0},
+		{1, false, 1},
+		{1, true, 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			states := []input.State{{Keys: []ebiten.Key{ebiten.KeyEscape}}, {}}
+			g := New(10, 10, newScene(tt.previous), newScene(tt.previous)).SetSource(input.SourceFunc(func() (input.State, error) {
+				s := states[0]
+				states = states[1:]
+				return s, nil
+			}))
+			g.scene.current = tt.current
+			g.Update()
+			if g.scene.current != tt.want {
+				t.Errorf("going back should move to the scene %d, but got %d", tt.want, g.scene.current)
+			}
+		})
+	}
+}
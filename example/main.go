@@ -86,6 +86,16 @@ func main() {
 						),
 						types.MarginAll(10),
 					),
+					craft.NewBox(
+						craft.NewButton(
+							craft.NewFill(types.Size{X: 60, Y: 30}, color.RGBA{0x40, 0x40, 0x40, 0xff}),
+							func(b *craft.Button) error { slog.Debug("Button.OnClick", "label", b.Label()); return nil },
+						).
+							SetCraft(craft.ButtonHover, craft.NewFill(types.Size{X: 60, Y: 30}, color.RGBA{0x60, 0x60, 0x60, 0xff})).
+							SetCraft(craft.ButtonPressed, craft.NewFill(types.Size{X: 60, Y: 30}, color.RGBA{0x20, 0x20, 0x20, 0xff})).
+							SetLabel("Button", color.White),
+						types.MarginAll(10),
+					),
 				),
 				craft.NewBox(
 					craft.NewImage(ebitenPng),
@@ -0,0 +1,266 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Actions used by etk itself.
+const (
+	ActionConfirm = "confirm"
+	ActionBack    = "back"
+	ActionUp      = "up"
+	ActionDown    = "down"
+	ActionLeft    = "left"
+	ActionRight   = "right"
+)
+
+// Device of a binding
+type Device int
+
+const (
+	KeyboardDevice Device = iota
+	MouseDevice
+	GamepadDevice
+)
+
+// Binding is a key, a mouse button or a standard gamepad button bound to an action.
+//
+// It is written as "Key:Enter", "Mouse:Left" or "Gamepad:RightBottom" in config files.
+type Binding struct {
+	Device  Device
+	Key     ebiten.Key
+	Mouse   ebiten.MouseButton
+	Gamepad ebiten.StandardGamepadButton
+}
+
+func KeyBinding(k ebiten.Key) Binding {
+	return Binding{Device: KeyboardDevice, Key: k}
+}
+
+func MouseBinding(b ebiten.MouseButton) Binding {
+	return Binding{Device: MouseDevice, Mouse: b}
+}
+
+func GamepadBinding(b ebiten.StandardGamepadButton) Binding {
+	return Binding{Device: GamepadDevice, Gamepad: b}
+}
+
+var mouseNames = []string{"Left", "Middle", "Right", "Back", "Forward"}
+
+var gamepadNames = []string{
+	"RightBottom", "RightRight", "RightLeft", "RightTop",
+	"FrontTopLeft", "FrontTopRight", "FrontBottomLeft", "FrontBottomRight",
+	"CenterLeft", "CenterRight", "LeftStick", "RightStick",
+	"LeftTop", "LeftBottom", "LeftLeft", "LeftRight", "CenterCenter",
+}
+
+func (b Binding) String() string {
+	switch b.Device {
+	case KeyboardDevice:
+		return "Key:" + b.Key.String()
+	case MouseDevice:
+		if int(b.Mouse) < len(mouseNames) {
+			return "Mouse:" + mouseNames[b.Mouse]
+		}
+	case GamepadDevice:
+		if int(b.Gamepad) < len(gamepadNames) {
+			return "Gamepad:" + gamepadNames[b.Gamepad]
+		}
+	}
+	return fmt.Sprintf("Unknown:%d", b.Device)
+}
+
+func (b Binding) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+func (b *Binding) UnmarshalText(text []byte) error {
+	device, name, _ := strings.Cut(string(text), ":")
+	switch device {
+	case "Key":
+		var k ebiten.Key
+		if err := k.UnmarshalText([]byte(name)); err != nil {
+			return fmt.Errorf("input: %w", err)
+		}
+		*b = KeyBinding(k)
+		return nil
+	case "Mouse":
+		if i := slices.Index(mouseNames, name); i >= 0 {
+			*b = MouseBinding(ebiten.MouseButton(i))
+			return nil
+		}
+	case "Gamepad":
+		if i := slices.Index(gamepadNames, name); i >= 0 {
+			*b = GamepadBinding(ebiten.StandardGamepadButton(i))
+			return nil
+		}
+	}
+	return fmt.Errorf("input: unknown binding %q", text)
+}
+
+// duration is the number of ticks b has been held.
+func (b Binding) duration(i *Input) int {
+	switch b.Device {
+	case KeyboardDevice:
+		return i.keys[b.Key]
+	case MouseDevice:
+		return i.buttons[b.Mouse]
+	case GamepadDevice:
+		return i.gamepadButtons[b.Gamepad]
+	}
+	return 0
+}
+
+func (b Binding) justReleased(i *Input) bool {
+	switch b.Device {
+	case KeyboardDevice:
+		return i.IsKeyJustReleased(b.Key)
+	case MouseDevice:
+		return i.IsButtonJustReleased(b.Mouse)
+	case GamepadDevice:
+		return i.IsGamepadButtonJustReleased(b.Gamepad)
+	}
+	return false
+}
+
+// Map maps named actions such as "confirm" or "jump" to bindings.
+// The bindings can be changed at any time and saved to a config file.
+type Map struct {
+	bindings map[string][]Binding
+}
+
+func NewMap() *Map {
+	return &Map{map[string][]Binding{}}
+}
+
+// DefaultMap binds the actions used by etk:
+// confirm to Enter, Space, the left button and A, back to Escape and B,
+// and the directions to the arrow keys and the D-pad.
+// Back closes popups; it leaves a scene only if the scene opts in, see etk.Scene.
+func DefaultMap() *Map {
+	return NewMap().
+		Bind(ActionConfirm, KeyBinding(ebiten.KeyEnter), KeyBinding(ebiten.KeyNumpadEnter), KeyBinding(ebiten.KeySpace),
+			MouseBinding(ebiten.MouseButtonLeft), GamepadBinding(ebiten.StandardGamepadButtonRightBottom)).
+		Bind(ActionBack, KeyBinding(ebiten.KeyEscape), GamepadBinding(ebiten.StandardGamepadButtonRightRight)).
+		Bind(ActionUp, KeyBinding(ebiten.KeyArrowUp), GamepadBinding(ebiten.StandardGamepadButtonLeftTop)).
+		Bind(ActionDown, KeyBinding(ebiten.KeyArrowDown), GamepadBinding(ebiten.StandardGamepadButtonLeftBottom)).
+		Bind(ActionLeft, KeyBinding(ebiten.KeyArrowLeft), GamepadBinding(ebiten.StandardGamepadButtonLeftLeft)).
+		Bind(ActionRight, KeyBinding(ebiten.KeyArrowRight), GamepadBinding(ebiten.StandardGamepadButtonLeftRight))
+}
+
+// Bind adds bindings to action.
+func (m *Map) Bind(action string, bindings ...Binding) *Map {
+	for _, b := range bindings {
+		if !slices.Contains(m.bindings[action], b) {
+			m.bindings[action] = append(m.bindings[action], b)
+		}
+	}
+	return m
+}
+
+// Rebind replaces the bindings of action.
+func (m *Map) Rebind(action string, bindings ...Binding) *Map {
+	delete(m.bindings, action)
+	return m.Bind(action, bindings...)
+}
+
+// Unbind removes bindings from action, or all of them if none are given.
+func (m *Map) Unbind(action string, bindings ...Binding) *Map {
+	if len(bindings) == 0 {
+		delete(m.bindings, action)
+		return m
+	}
+	m.bindings[action] = slices.DeleteFunc(m.bindings[action], func(b Binding) bool {
+		return slices.Contains(bindings, b)
+	})
+	return m
+}
+
+func (m *Map) Bindings(action string) []Binding {
+	return slices.Clone(m.bindings[action])
+}
+
+// Actions returns the names of the actions in order.
+func (m *Map) Actions() []string {
+	actions := make([]string, 0, len(m.bindings))
+	for a := range m.bindings {
+		actions = append(actions, a)
+	}
+	slices.Sort(actions)
+	return actions
+}
+
+// Has reports whether b is bound to action.
+func (m *Map) Has(action string, b Binding) bool {
+	return slices.Contains(m.bindings[action], b)
+}
+
+// IsPressed reports whether any binding of action is held.
+func (m *Map) IsPressed(in *Input, action string) bool {
+	return slices.ContainsFunc(m.bindings[action], func(b Binding) bool { return b.duration(in) > 0 })
+}
+
+// IsJustPressed reports whether a binding of action was pressed in this tick.
+func (m *Map) IsJustPressed(in *Input, action string) bool {
+	return slices.ContainsFunc(m.bindings[action], func(b Binding) bool { return b.duration(in) == 1 })
+}
+
+// IsJustReleased reports whether action was released in this tick:
+// a binding was released and none is held.
+func (m *Map) IsJustReleased(in *Input, action string) bool {
+	return !m.IsPressed(in, action) &&
+		slices.ContainsFunc(m.bindings[action], func(b Binding) bool { return b.justReleased(in) })
+}
+
+// Save writes the bindings as JSON:
+//
+//	{"confirm": ["Key:Enter", "Gamepad:RightBottom"]}
+func (m *Map) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(m.bindings); err != nil {
+		return fmt.Errorf("input: %w", err)
+	}
+	return nil
+}
+
+// Load reads bindings written by Save.
+// The actions in the config replace their bindings; the others are kept.
+func (m *Map) Load(r io.Reader) error {
+	var bindings map[string][]Binding
+	if err := json.NewDecoder(r).Decode(&bindings); err != nil {
+		return fmt.Errorf("input: %w", err)
+	}
+	for action, bs := range bindings {
+		m.Rebind(action, bs...)
+	}
+	return nil
+}
+
+func (m *Map) SaveFile(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("input: %w", err)
+	}
+	defer f.Close()
+	if err := m.Save(f); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func (m *Map) LoadFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("input: %w", err)
+	}
+	defer f.Close()
+	return m.Load(f)
+}
@@ -0,0 +1,152 @@
+package input
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestBindingText(t *testing.T) {
+	tests := []struct {
+		binding Binding
+		text    string
+	}{
+		{KeyBinding(ebiten.KeyEnter), "Key:Enter"},
+		{KeyBinding(ebiten.KeyArrowUp), "Key:ArrowUp"},
+		{MouseBinding(ebiten.MouseButtonRight), "Mouse:Right"},
+		{GamepadBinding(ebiten.StandardGamepadButtonRightBottom), "Gamepad:RightBottom"},
+		{GamepadBinding(ebiten.StandardGamepadButtonCenterCenter), "Gamepad:CenterCenter"},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			if got := tt.binding.String(); got != tt.text {
+				t.Errorf("String should return %q, but got %q", tt.text, got)
+			}
+			var b Binding
+			if err := b.UnmarshalText([]byte(tt.text)); err != nil {
+				t.Fatalf("UnmarshalText should not return an error, but got %v", err)
+			}
+			if b != tt.binding {
+				t.Errorf("UnmarshalText should set %v, but got %v", tt.binding, b)
+			}
+		})
+	}
+
+	for _, text := range []string{"Key:Nope", "Mouse:Nope", "Pad:A", ""} {
+		var b Binding
+		if err := b.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q) should return an error, but got nil", text)
+		}
+	}
+}
+
+func TestMapBind(t *testing.T) {
+	space, enter := KeyBinding(ebiten.KeySpace), KeyBinding(ebiten.KeyEnter)
+	a := GamepadBinding(ebiten.StandardGamepadButtonRightBottom)
+
+	m := NewMap().Bind("jump", space, a).Bind("jump", space)
+	if got := m.Bindings("jump"); fmt.Sprint(got) != fmt.Sprint([]Binding{space, a}) {
+		t.Errorf("Bindings should return [%v %v], but got %v", space, a, got)
+	}
+
+	m.Rebind("jump", enter)
+	if m.Has("jump", space) || !m.Has("jump", enter) {
+		t.Errorf("Rebind should replace the bindings with %v, but got %v", enter, m.Bindings("jump"))
+	}
+
+	m.Bind("jump", a).Unbind("jump", enter)
+	if got := m.Bindings("jump"); fmt.Sprint(got) != fmt.Sprint([]Binding{a}) {
+		t.Errorf("Unbind should remove %v, but got %v", enter, got)
+	}
+
+	m.Bind("fire", space).Unbind("jump")
+	if got := m.Actions(); fmt.Sprint(got) != "[fire]" {
+		t.Errorf("Actions should return [fire], but got %v", got)
+	}
+}
+
+func TestMapPressed(t *testing.T) {
+	m := NewMap().Bind("jump",
+		KeyBinding(ebiten.KeySpace),
+		MouseBinding(ebiten.MouseButtonLeft),
+		GamepadBinding(ebiten.StandardGamepadButtonRightBottom),
+	)
+	space := []ebiten.Key{ebiten.KeySpace}
+	left := []ebiten.MouseButton{ebiten.MouseButtonLeft}
+	a := []Gamepad{{ID: 0, Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom}}}
+
+	tests := []struct {
+		state    State
+		pressed  bool
+		just     bool
+		released bool
+	}{
+		{State{}, false, false, false},
+		{State{Keys: space}, true, true, false},
+		{State{Keys: space, Buttons: left}, true, true, false},
+		// still held by the button
+		{State{Buttons: left}, true, false, false},
+		{State{}, false, false, true},
+		{State{Gamepads: a}, true, true, false},
+		{State{}, false, false, true},
+		{State{Keys: []ebiten.Key{ebiten.KeyEnter}}, false, false, false},
+	}
+
+	in := New()
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			in.Update(tt.state)
+			if got := m.IsPressed(in, "jump"); got != tt.pressed {
+				t.Errorf("IsPressed should return %v, but got %v", tt.pressed, got)
+			}
+			if got := m.IsJustPressed(in, "jump"); got != tt.just {
+				t.Errorf("IsJustPressed should return %v, but got %v", tt.just, got)
+			}
+			if got := m.IsJustReleased(in, "jump"); got != tt.released {
+				t.Errorf("IsJustReleased should return %v, but got %v", tt.released, got)
+			}
+		})
+	}
+}
+
+func TestMapSaveLoad(t *testing.T) {
+	saved := DefaultMap().Rebind(ActionConfirm, KeyBinding(ebiten.KeyZ), GamepadBinding(ebiten.StandardGamepadButtonRightTop))
+	buf := &bytes.Buffer{}
+	if err := saved.Save(buf); err != nil {
+		t.Fatalf("Save should not return an error, but got %v", err)
+	}
+
+	// Actions missing from the config keep their bindings.
+	m := DefaultMap().Bind("jump", KeyBinding(ebiten.KeySpace))
+	if err := m.Load(buf); err != nil {
+		t.Fatalf("Load should not return an error, but got %v", err)
+	}
+	for _, a := range saved.Actions() {
+		if fmt.Sprint(m.Bindings(a)) != fmt.Sprint(saved.Bindings(a)) {
+			t.Errorf("Bindings(%q) should return %v, but got %v", a, saved.Bindings(a), m.Bindings(a))
+		}
+	}
+	if !m.Has("jump", KeyBinding(ebiten.KeySpace)) {
+		t.Errorf("Load should keep the bindings of jump, but got %v", m.Bindings("jump"))
+	}
+
+	if err := NewMap().Load(bytes.NewBufferString(`{"jump": ["Key:Nope"]}`)); err == nil {
+		t.Errorf("Load should return an error for an unknown key, but got nil")
+	}
+
+	name := filepath.Join(t.TempDir(), "actions.json")
+	if err := saved.SaveFile(name); err != nil {
+		t.Fatalf("SaveFile should not return an error, but got %v", err)
+	}
+	m = NewMap()
+	if err := m.LoadFile(name); err != nil {
+		t.Fatalf("LoadFile should not return an error, but got %v", err)
+	}
+	if fmt.Sprint(m.Bindings(ActionConfirm)) != fmt.Sprint(saved.Bindings(ActionConfirm)) {
+		t.Errorf("LoadFile should load %v, but got %v", saved.Bindings(ActionConfirm), m.Bindings(ActionConfirm))
+	}
+}
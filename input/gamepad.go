@@ -0,0 +1,148 @@
+package input
+
+import (
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Gamepad is a gamepad with the standard layout.
+// Gamepads without it are ignored.
+type Gamepad struct {
+	ID      ebiten.GamepadID
+	Buttons []ebiten.StandardGamepadButton
+	// Axes is indexed by ebiten.StandardGamepadAxis.
+	Axes []float64
+}
+
+// StickThreshold is how far the left stick must be tilted to act as the D-pad.
+const StickThreshold = 0.5
+
+func collectGamepads() []Gamepad {
+	var gamepads []Gamepad
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		g := Gamepad{ID: id}
+		for b := ebiten.StandardGamepadButton(0); b <= ebiten.StandardGamepadButtonMax; b++ {
+			if ebiten.IsStandardGamepadButtonPressed(id, b) {
+				g.Buttons = append(g.Buttons, b)
+			}
+		}
+		for a := ebiten.StandardGamepadAxis(0); a <= ebiten.StandardGamepadAxisMax; a++ {
+			g.Axes = append(g.Axes, ebiten.StandardGamepadAxisValue(id, a))
+		}
+		gamepads = append(gamepads, g)
+	}
+	return gamepads
+}
+
+// pressed returns the buttons of g, with the left stick tilted past StickThreshold as the D-pad.
+func (g Gamepad) pressed() []ebiten.StandardGamepadButton {
+	buttons := slices.Clone(g.Buttons)
+	press := func(b ebiten.StandardGamepadButton) {
+		if !slices.Contains(buttons, b) {
+			buttons = append(buttons, b)
+		}
+	}
+	axis := func(a ebiten.StandardGamepadAxis) float64 {
+		if int(a) < len(g.Axes) {
+			return g.Axes[a]
+		}
+		return 0
+	}
+
+	x, y := axis(ebiten.StandardGamepadAxisLeftStickHorizontal), axis(ebiten.StandardGamepadAxisLeftStickVertical)
+	if math.Abs(x) >= math.Abs(y) {
+		switch {
+		case x <= -StickThreshold:
+			press(ebiten.StandardGamepadButtonLeftLeft)
+		case x >= StickThreshold:
+			press(ebiten.StandardGamepadButtonLeftRight)
+		}
+	} else {
+		switch {
+		case y <= -StickThreshold:
+			press(ebiten.StandardGamepadButtonLeftTop)
+		case y >= StickThreshold:
+			press(ebiten.StandardGamepadButtonLeftBottom)
+		}
+	}
+	return buttons
+}
+
+// gamepadButtons returns the buttons pressed on any gamepad.
+func gamepadButtons(gamepads []Gamepad) []ebiten.StandardGamepadButton {
+	var buttons []ebiten.StandardGamepadButton
+	for _, g := range gamepads {
+		for _, b := range g.pressed() {
+			if !slices.Contains(buttons, b) {
+				buttons = append(buttons, b)
+			}
+		}
+	}
+	return buttons
+}
+
+func (i *Input) Gamepads() []Gamepad {
+	return i.curr.Gamepads
+}
+
+// GamepadButtons returns the buttons pressed on any gamepad.
+// The left stick counts as the D-pad.
+func (i *Input) GamepadButtons() []ebiten.StandardGamepadButton {
+	return gamepadButtons(i.curr.Gamepads)
+}
+
+func (i *Input) IsGamepadButtonPressed(b ebiten.StandardGamepadButton) bool {
+	return i.gamepadButtons[b] > 0
+}
+
+func (i *Input) IsGamepadButtonJustPressed(b ebiten.StandardGamepadButton) bool {
+	return i.gamepadButtons[b] == 1
+}
+
+func (i *Input) IsGamepadButtonJustReleased(b ebiten.StandardGamepadButton) bool {
+	return i.gamepadButtons[b] == 0 && slices.Contains(gamepadButtons(i.prev.Gamepads), b)
+}
+
+// IsGamepadButtonRepeated reports whether b was just pressed or is auto-repeating,
+// at the same rate as keys.
+func (i *Input) IsGamepadButtonRepeated(b ebiten.StandardGamepadButton) bool {
+	return repeated(i.gamepadButtons[b])
+}
+
+// JustReleasedGamepadButtons returns the buttons released in this tick,
+// including those of gamepads just disconnected.
+func (i *Input) JustReleasedGamepadButtons() []ebiten.StandardGamepadButton {
+	var released []ebiten.StandardGamepadButton
+	for _, b := range gamepadButtons(i.prev.Gamepads) {
+		if i.gamepadButtons[b] == 0 {
+			released = append(released, b)
+		}
+	}
+	return released
+}
+
+// JustConnectedGamepads returns the gamepads connected in this tick.
+func (i *Input) JustConnectedGamepads() []ebiten.GamepadID {
+	return diffGamepads(i.curr.Gamepads, i.prev.Gamepads)
+}
+
+// JustDisconnectedGamepads returns the gamepads disconnected in this tick.
+func (i *Input) JustDisconnectedGamepads() []ebiten.GamepadID {
+	return diffGamepads(i.prev.Gamepads, i.curr.Gamepads)
+}
+
+// diffGamepads returns the ids of a not in b.
+func diffGamepads(a, b []Gamepad) []ebiten.GamepadID {
+	var ids []ebiten.GamepadID
+	for _, g := range a {
+		if !slices.ContainsFunc(b, func(h Gamepad) bool { return h.ID == g.ID }) {
+			ids = append(ids, g.ID)
+		}
+	}
+	return ids
+}
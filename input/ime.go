@@ -0,0 +1,66 @@
+package input
+
+import (
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+)
+
+// ime is the text input session of an IME, kept while a text craft asks for it.
+var ime struct {
+	states      chan textinput.State
+	end         func()
+	composition string
+	wanted      bool
+	at          types.Position
+}
+
+// ComposeAt asks for the text of an IME, with its window at p, for the next tick.
+// Text crafts call it on every tick while they are focused, and the session ends
+// on the first tick nothing asks for it.
+func ComposeAt(p types.Position) {
+	ime.wanted, ime.at = true, p
+}
+
+// collectIME reads the text committed and composed by the IME for Collect.
+// It returns false if no session is wanted or the platform has none,
+// and the input chars of ebiten are used instead.
+func collectIME() (chars []rune, composition string, ok bool) {
+	wanted := ime.wanted
+	ime.wanted = false
+	if !wanted {
+		endIME()
+		return nil, "", false
+	}
+	if ime.states == nil {
+		ime.states, ime.end = textinput.Start(ime.at.X, ime.at.Y)
+		if ime.states == nil {
+			return nil, "", false
+		}
+	}
+
+	for {
+		select {
+		case s, open := <-ime.states:
+			if !open || s.Error != nil {
+				// The session is started again on the next tick.
+				ime.states, ime.end, ime.composition = nil, nil, ""
+				return chars, "", true
+			}
+			if s.Committed {
+				chars = append(chars, []rune(s.Text)...)
+				ime.composition = ""
+				continue
+			}
+			ime.composition = s.Text
+		default:
+			return chars, ime.composition, true
+		}
+	}
+}
+
+func endIME() {
+	if ime.end != nil {
+		ime.end()
+	}
+	ime.states, ime.end, ime.composition = nil, nil, ""
+}
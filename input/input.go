@@ -0,0 +1,242 @@
+package input
+
+import (
+	"slices"
+
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// State is the raw input of a tick.
+type State struct {
+	Cursor  types.Position
+	Buttons []ebiten.MouseButton
+	Keys    []ebiten.Key
+	Chars   []rune
+	// Composition is the text an IME is composing, not committed yet.
+	Composition string
+	WheelX      float64
+	WheelY      float64
+	Touches     []Touch
+	Gamepads    []Gamepad
+}
+
+// Touch is a finger on the screen.
+type Touch struct {
+	ID       ebiten.TouchID
+	Position types.Position
+}
+
+// Collect reads the input of the current tick from ebiten.
+func Collect() State {
+	s := State{}
+	x, y := ebiten.CursorPosition()
+	s.Cursor = types.Position{X: x, Y: y}
+	for b := ebiten.MouseButton0; b <= ebiten.MouseButtonMax; b++ {
+		if ebiten.IsMouseButtonPressed(b) {
+			s.Buttons = append(s.Buttons, b)
+		}
+	}
+	s.Keys = inpututil.AppendPressedKeys(nil)
+	// While an IME session is open, it gives the committed text instead of the input chars.
+	if chars, composition, ok := collectIME(); ok {
+		s.Chars, s.Composition = chars, composition
+	} else {
+		s.Chars = ebiten.AppendInputChars(nil)
+	}
+	s.WheelX, s.WheelY = ebiten.Wheel()
+	for _, id := range ebiten.AppendTouchIDs(nil) {
+		x, y := ebiten.TouchPosition(id)
+		s.Touches = append(s.Touches, Touch{id, types.Position{X: x, Y: y}})
+	}
+	s.Gamepads = collectGamepads()
+	return s
+}
+
+const (
+	repeatDelay    = 30
+	repeatInterval = 3
+)
+
+// Input tracks the input across ticks.
+// Everything is derived from the States given to Update,
+// so the same States always produce the same Input.
+type Input struct {
+	prev    State
+	curr    State
+	buttons map[ebiten.MouseButton]int
+	keys    map[ebiten.Key]int
+	touches map[ebiten.TouchID]int
+
+	gamepadButtons map[ebiten.StandardGamepadButton]int
+}
+
+func New() *Input {
+	return &Input{
+		buttons: map[ebiten.MouseButton]int{},
+		keys:    map[ebiten.Key]int{},
+		touches: map[ebiten.TouchID]int{},
+
+		gamepadButtons: map[ebiten.StandardGamepadButton]int{},
+	}
+}
+
+// Update advances a tick.
+func (i *Input) Update(s State) {
+	i.prev, i.curr = i.curr, s
+	i.buttons = durations(i.buttons, s.Buttons)
+	i.keys = durations(i.keys, s.Keys)
+	i.touches = durations(i.touches, touchIDs(s.Touches))
+	i.gamepadButtons = durations(i.gamepadButtons, gamepadButtons(s.Gamepads))
+}
+
+func touchIDs(touches []Touch) []ebiten.TouchID {
+	ids := make([]ebiten.TouchID, len(touches))
+	for i, t := range touches {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+func durations[T comparable](prev map[T]int, pressed []T) map[T]int {
+	m := make(map[T]int, len(pressed))
+	for _, v := range pressed {
+		m[v] = prev[v] + 1
+	}
+	return m
+}
+
+func (i *Input) State() State {
+	return i.curr
+}
+
+func (i *Input) Cursor() types.Position {
+	return i.curr.Cursor
+}
+
+// CursorMoved reports whether the cursor moved since the previous tick.
+func (i *Input) CursorMoved() bool {
+	return i.curr.Cursor != i.prev.Cursor
+}
+
+func (i *Input) Chars() []rune {
+	return i.curr.Chars
+}
+
+// Composition returns the text an IME is composing.
+func (i *Input) Composition() string {
+	return i.curr.Composition
+}
+
+// CompositionChanged reports whether the composition changed since the previous tick.
+func (i *Input) CompositionChanged() bool {
+	return i.curr.Composition != i.prev.Composition
+}
+
+func (i *Input) Wheel() (float64, float64) {
+	return i.curr.WheelX, i.curr.WheelY
+}
+
+func (i *Input) Buttons() []ebiten.MouseButton {
+	return i.curr.Buttons
+}
+
+func (i *Input) IsButtonPressed(b ebiten.MouseButton) bool {
+	return i.buttons[b] > 0
+}
+
+func (i *Input) IsButtonJustPressed(b ebiten.MouseButton) bool {
+	return i.buttons[b] == 1
+}
+
+func (i *Input) IsButtonJustReleased(b ebiten.MouseButton) bool {
+	return i.buttons[b] == 0 && slices.Contains(i.prev.Buttons, b)
+}
+
+// JustReleasedButtons returns the buttons released in this tick.
+func (i *Input) JustReleasedButtons() []ebiten.MouseButton {
+	var released []ebiten.MouseButton
+	for _, b := range i.prev.Buttons {
+		if i.buttons[b] == 0 {
+			released = append(released, b)
+		}
+	}
+	return released
+}
+
+func (i *Input) Keys() []ebiten.Key {
+	return i.curr.Keys
+}
+
+func (i *Input) IsKeyPressed(k ebiten.Key) bool {
+	return i.keys[k] > 0
+}
+
+func (i *Input) IsKeyJustPressed(k ebiten.Key) bool {
+	return i.keys[k] == 1
+}
+
+func (i *Input) IsKeyJustReleased(k ebiten.Key) bool {
+	return i.keys[k] == 0 && slices.Contains(i.prev.Keys, k)
+}
+
+// KeyPressDuration is the number of ticks k has been pressed.
+func (i *Input) KeyPressDuration(k ebiten.Key) int {
+	return i.keys[k]
+}
+
+// IsKeyRepeated reports whether k was just pressed or is auto-repeating.
+func (i *Input) IsKeyRepeated(k ebiten.Key) bool {
+	return repeated(i.keys[k])
+}
+
+// repeated reports whether an input held for d ticks fires in this tick.
+func repeated(d int) bool {
+	return d == 1 || (d >= repeatDelay && (d-repeatDelay)%repeatInterval == 0)
+}
+
+// JustReleasedKeys returns the keys released in this tick.
+func (i *Input) JustReleasedKeys() []ebiten.Key {
+	var released []ebiten.Key
+	for _, k := range i.prev.Keys {
+		if i.keys[k] == 0 {
+			released = append(released, k)
+		}
+	}
+	return released
+}
+
+func (i *Input) Touches() []Touch {
+	return i.curr.Touches
+}
+
+func (i *Input) IsTouchJustPressed(id ebiten.TouchID) bool {
+	return i.touches[id] == 1
+}
+
+// TouchPressDuration is the number of ticks the touch id has been on the screen.
+func (i *Input) TouchPressDuration(id ebiten.TouchID) int {
+	return i.touches[id]
+}
+
+// TouchMoved reports whether the touch t moved since the previous tick.
+func (i *Input) TouchMoved(t Touch) bool {
+	for _, p := range i.prev.Touches {
+		if p.ID == t.ID {
+			return p.Position != t.Position
+		}
+	}
+	return false
+}
+
+// JustReleasedTouches returns the touches released in this tick at their last positions.
+func (i *Input) JustReleasedTouches() []Touch {
+	var released []Touch
+	for _, t := range i.prev.Touches {
+		if i.touches[t.ID] == 0 {
+			released = append(released, t)
+		}
+	}
+	return released
+}
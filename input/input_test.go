@@ -0,0 +1,171 @@
+package input
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestInputButtons(t *testing.T) {
+	left := []ebiten.MouseButton{ebiten.MouseButtonLeft}
+	tests := []struct {
+		state    State
+		pressed  bool
+		just     bool
+		released bool
+	}{
+		{State{}, false, false, false},
+		{State{Buttons: left}, true, true, false},
+		{State{Buttons: left}, true, false, false},
+		{State{}, false, false, true},
+		{State{}, false, false, false},
+	}
+
+	i := New()
+	for n, tt := range tests {
+		t.Run(fmt.Sprint(n+1), func(t *testing.T) {
+			i.Update(tt.state)
+			if got := i.IsButtonPressed(ebiten.MouseButtonLeft); got != tt.pressed {
+				t.Errorf("IsButtonPressed should return %v, but got %v", tt.pressed, got)
+			}
+			if got := i.IsButtonJustPressed(ebiten.MouseButtonLeft); got != tt.just {
+				t.Errorf("IsButtonJustPressed should return %v, but got %v", tt.just, got)
+			}
+			if got := i.IsButtonJustReleased(ebiten.MouseButtonLeft); got != tt.released {
+				t.Errorf("IsButtonJustReleased should return %v, but got %v", tt.released, got)
+			}
+		})
+	}
+}
+
+func TestInputIsKeyRepeated(t *testing.T) {
+	i := New()
+	repeated := []int{}
+	for tick := 1; tick <= 40; tick++ {
+		i.Update(State{Keys: []ebiten.Key{ebiten.KeyA}})
+		if i.IsKeyRepeated(ebiten.KeyA) {
+			repeated = append(repeated, tick)
+		}
+	}
+
+	want := []int{1, 30, 33, 36, 39}
+	if fmt.Sprint(repeated) != fmt.Sprint(want) {
+		t.Errorf("IsKeyRepeated should be true at %v, but got %v", want, repeated)
+	}
+}
+
+func TestInputJustReleasedKeys(t *testing.T) {
+	i := New()
+	i.Update(State{Keys: []ebiten.Key{ebiten.KeyA, ebiten.KeyB}})
+	i.Update(State{Keys: []ebiten.Key{ebiten.KeyB}})
+
+	want := []ebiten.Key{ebiten.KeyA}
+	if got := i.JustReleasedKeys(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("JustReleasedKeys should return %v, but got %v", want, got)
+	}
+	if got := i.KeyPressDuration(ebiten.KeyB); got != 2 {
+		t.Errorf("KeyPressDuration should return 2, but got %d", got)
+	}
+}
+
+func TestInputComposition(t *testing.T) {
+	i := New()
+
+	tests := []struct {
+		composition string
+		changed     bool
+	}{
+		{"", false},
+		{"に", true},
+		{"に", false},
+		{"", true},
+	}
+
+	for n, tt := range tests {
+		t.Run(fmt.Sprint(n+1), func(t *testing.T) {
+			i.Update(State{Composition: tt.composition})
+			if got := i.CompositionChanged(); got != tt.changed {
+				t.Errorf("CompositionChanged should return %v, but got %v", tt.changed, got)
+			}
+		})
+	}
+}
+
+func TestInputTouches(t *testing.T) {
+	i := New()
+	i.Update(State{Touches: []Touch{{1, types.Position{X: 1, Y: 1}}}})
+	if !i.IsTouchJustPressed(1) {
+		t.Errorf("IsTouchJustPressed should return true, but got false")
+	}
+
+	i.Update(State{Touches: []Touch{{1, types.Position{X: 2, Y: 1}}, {2, types.Position{X: 5, Y: 5}}}})
+	if !i.TouchMoved(i.Touches()[0]) {
+		t.Errorf("TouchMoved should return true, but got false")
+	}
+	if i.TouchMoved(i.Touches()[1]) {
+		t.Errorf("TouchMoved should return false for a new touch, but got true")
+	}
+
+	i.Update(State{Touches: []Touch{{2, types.Position{X: 5, Y: 5}}}})
+	want := []Touch{{1, types.Position{X: 2, Y: 1}}}
+	if got := i.JustReleasedTouches(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("JustReleasedTouches should return %v, but got %v", want, got)
+	}
+	if got := i.TouchPressDuration(2); got != 2 {
+		t.Errorf("TouchPressDuration should return 2, but got %d", got)
+	}
+}
+
+func TestInputGamepads(t *testing.T) {
+	axes := func(x, y float64) []float64 {
+		a := make([]float64, ebiten.StandardGamepadAxisMax+1)
+		a[ebiten.StandardGamepadAxisLeftStickHorizontal] = x
+		a[ebiten.StandardGamepadAxisLeftStickVertical] = y
+		return a
+	}
+
+	tests := []struct {
+		gamepads     []Gamepad
+		buttons      []ebiten.StandardGamepadButton
+		released     []ebiten.StandardGamepadButton
+		connected    []ebiten.GamepadID
+		disconnected []ebiten.GamepadID
+	}{
+		{
+			[]Gamepad{{0, []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom}, axes(0, 0)}},
+			[]ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom},
+			nil, []ebiten.GamepadID{0}, nil,
+		},
+		{
+			[]Gamepad{{0, nil, axes(0.2, -0.8)}, {1, nil, axes(0.9, 0.1)}},
+			[]ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftTop, ebiten.StandardGamepadButtonLeftRight},
+			[]ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom}, []ebiten.GamepadID{1}, nil,
+		},
+		{
+			[]Gamepad{{0, nil, axes(0, 0)}},
+			nil,
+			[]ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftTop, ebiten.StandardGamepadButtonLeftRight}, nil, []ebiten.GamepadID{1},
+		},
+	}
+
+	i := New()
+	for n, tt := range tests {
+		t.Run(fmt.Sprint(n+1), func(t *testing.T) {
+			i.Update(State{Gamepads: tt.gamepads})
+			if got := i.GamepadButtons(); fmt.Sprint(got) != fmt.Sprint(tt.buttons) {
+				t.Errorf("GamepadButtons should return %v, but got %v", tt.buttons, got)
+			}
+			if got := i.JustReleasedGamepadButtons(); fmt.Sprint(got) != fmt.Sprint(tt.released) {
+				t.Errorf("JustReleasedGamepadButtons should return %v, but got %v", tt.released, got)
+			}
+			if got := i.JustConnectedGamepads(); fmt.Sprint(got) != fmt.Sprint(tt.connected) {
+				t.Errorf("JustConnectedGamepads should return %v, but got %v", tt.connected, got)
+			}
+			if got := i.JustDisconnectedGamepads(); fmt.Sprint(got) != fmt.Sprint(tt.disconnected) {
+				t.Errorf("JustDisconnectedGamepads should return %v, but got %v", tt.disconnected, got)
+			}
+		})
+	}
+}
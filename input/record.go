@@ -0,0 +1,113 @@
+package input
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Source provides the raw input of each tick.
+// Game reads from Live unless it replays a recording.
+type Source interface {
+	Read() (State, error)
+}
+
+// SourceFunc is a function used as a Source.
+type SourceFunc func() (State, error)
+
+func (f SourceFunc) Read() (State, error) {
+	return f()
+}
+
+// Live reads the input from ebiten.
+var Live Source = SourceFunc(func() (State, error) { return Collect(), nil })
+
+// frame is a State held for a number of ticks.
+// Most ticks repeat the previous one, so they are stored once.
+type frame struct {
+	Ticks int
+	State State
+}
+
+// Recorder records the States read from a source, passing them through.
+// Call Close to write the last ticks.
+type Recorder struct {
+	source  Source
+	enc     *gob.Encoder
+	pending frame
+}
+
+func NewRecorder(source Source, w io.Writer) *Recorder {
+	return &Recorder{source: source, enc: gob.NewEncoder(w)}
+}
+
+func (r *Recorder) Read() (State, error) {
+	s, err := r.source.Read()
+	if err != nil {
+		return s, err
+	}
+	if r.pending.Ticks > 0 && equal(r.pending.State, s) {
+		r.pending.Ticks++
+		return s, nil
+	}
+	err = r.flush()
+	r.pending = frame{1, s}
+	return s, err
+}
+
+func (r *Recorder) flush() error {
+	if r.pending.Ticks == 0 {
+		return nil
+	}
+	if err := r.enc.Encode(r.pending); err != nil {
+		return fmt.Errorf("input: %w", err)
+	}
+	return nil
+}
+
+// Close writes the ticks not written yet.
+// The writer is left open.
+func (r *Recorder) Close() error {
+	err := r.flush()
+	r.pending = frame{}
+	return err
+}
+
+// Player replays a recording written by Recorder.
+// Read returns io.EOF once every tick has been replayed.
+type Player struct {
+	dec     *gob.Decoder
+	pending frame
+}
+
+func NewPlayer(r io.Reader) *Player {
+	return &Player{dec: gob.NewDecoder(r)}
+}
+
+func (p *Player) Read() (State, error) {
+	for p.pending.Ticks == 0 {
+		p.pending = frame{}
+		if err := p.dec.Decode(&p.pending); err != nil {
+			if errors.Is(err, io.EOF) {
+				return State{}, io.EOF
+			}
+			return State{}, fmt.Errorf("input: %w", err)
+		}
+	}
+	p.pending.Ticks--
+	return p.pending.State, nil
+}
+
+func equal(a, b State) bool {
+	return a.Cursor == b.Cursor &&
+		a.WheelX == b.WheelX && a.WheelY == b.WheelY &&
+		slices.Equal(a.Buttons, b.Buttons) &&
+		slices.Equal(a.Keys, b.Keys) &&
+		slices.Equal(a.Chars, b.Chars) && a.Composition == b.Composition &&
+		slices.Equal(a.Touches, b.Touches) &&
+		slices.EqualFunc(a.Gamepads, b.Gamepads, func(a, b Gamepad) bool {
+			return a.ID == b.ID && slices.Equal(a.Buttons, b.Buttons) && slices.Equal(a.Axes, b.Axes)
+		})
+}
@@ -0,0 +1,86 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/a-skua/etk/craft/types"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// script is a source reading the given States, then io.EOF.
+func script(states ...State) Source {
+	return SourceFunc(func() (State, error) {
+		if len(states) == 0 {
+			return State{}, io.EOF
+		}
+		s := states[0]
+		states = states[1:]
+		return s, nil
+	})
+}
+
+func TestRecordReplay(t *testing.T) {
+	states := []State{
+		{},
+		{},
+		{Cursor: types.Position{X: 1, Y: 2}},
+		{Cursor: types.Position{X: 1, Y: 2}, Buttons: []ebiten.MouseButton{ebiten.MouseButtonLeft}},
+		{Keys: []ebiten.Key{ebiten.KeyA}, Chars: []rune("a")},
+		{Touches: []Touch{{1, types.Position{X: 3, Y: 4}}}},
+		{Gamepads: []Gamepad{{ID: 0, Buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom}, Axes: []float64{0.5}}}},
+		{WheelY: -1},
+		{},
+		{},
+		{},
+	}
+
+	buf := &bytes.Buffer{}
+	r := NewRecorder(script(states...), buf)
+	for i := range states {
+		if _, err := r.Read(); err != nil {
+			t.Fatalf("Read %d should not return an error, but got %v", i+1, err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close should not return an error, but got %v", err)
+	}
+
+	p := NewPlayer(buf)
+	for i, want := range states {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			got, err := p.Read()
+			if err != nil {
+				t.Fatalf("Read should not return an error, but got %v", err)
+			}
+			if !equal(got, want) {
+				t.Errorf("Read should return %v, but got %v", want, got)
+			}
+		})
+	}
+	if _, err := p.Read(); !errors.Is(err, io.EOF) {
+		t.Errorf("Read should return io.EOF at the end, but got %v", err)
+	}
+}
+
+func TestRecorderFrames(t *testing.T) {
+	idle := &bytes.Buffer{}
+	r := NewRecorder(script(make([]State, 600)...), idle)
+	for range 600 {
+		r.Read()
+	}
+	r.Close()
+
+	one := &bytes.Buffer{}
+	r = NewRecorder(script(State{}), one)
+	r.Read()
+	r.Close()
+
+	// Idle ticks are stored as a single frame.
+	if idle.Len() > one.Len()+2 {
+		t.Errorf("600 idle ticks should take about %d bytes, but got %d", one.Len(), idle.Len())
+	}
+}